@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/cluster"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/utils"
+)
+
+// printClusters prints one representative headline per story, followed by
+// the outlets reporting it when there is more than one item
+func printClusters(clusters []cluster.Cluster) {
+	for _, c := range clusters {
+		item := c.Representative()
+		fmt.Printf("%s%s: %s", utils.Red, item.Title, utils.Reset)
+		fmt.Printf("%s%s%s\n", utils.Gray, item.URL, utils.Reset)
+		if len(c.Items) > 1 {
+			fmt.Printf("%s  %d haber: %s%s\n", utils.Gray, len(c.Items), strings.Join(c.Sources(), ", "), utils.Reset)
+		}
+	}
+}
+
+// representatives returns the representative item of every cluster
+func representatives(clusters []cluster.Cluster) []sources.NewsItem {
+	items := make([]sources.NewsItem, len(clusters))
+	for i, c := range clusters {
+		items[i] = c.Representative()
+	}
+	return items
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/cache"
+	"github.com/furkandogmus/HaberlerPlus/pkg/filter"
+)
+
+// commandOptions are the global flags that apply to subcommands
+type commandOptions struct {
+	// timeout limits the downloads of commands that fetch pages
+	timeout time.Duration
+	workers int
+	filter  *filter.Filter
+	offline bool
+}
+
+// runCommand runs a subcommand given as positional arguments, e.g.
+// "cache clear", and returns its exit code
+func runCommand(args []string, opts commandOptions) int {
+	switch args[0] {
+	case "cache":
+		return runCache(args[1:])
+	case "search":
+		return runSearch(args[1:])
+	case "read":
+		return runRead(args[1:], opts.timeout)
+	case "serve":
+		return runServe(args[1:], opts)
+	default:
+		return usageError("Bilinmeyen komut: %s", args[0])
+	}
+}
+
+// runCache handles the cache subcommands
+func runCache(args []string) int {
+	if len(args) != 1 || args[0] != "clear" {
+		return usageError("Kullanım: news cache clear")
+	}
+
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return usageError("Önbellek dizini bulunamadı: %v", err)
+	}
+	if err := cache.New(dir, 0).Clear(); err != nil {
+		fmt.Fprintf(os.Stderr, "Önbellek temizlenemedi: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Önbellek temizlendi: %s\n", dir)
+	return 0
+}
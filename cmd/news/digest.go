@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/output"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/utils"
+)
+
+// printDigest prints the results of a FetchAll run grouped by source and
+// category, followed by a summary of the categories that failed. In offline
+// mode every category is marked with the time it was fetched.
+func printDigest(results []sources.Result, offline bool) {
+	var current sources.NewsSource
+
+	for _, r := range results {
+		if r.Source != current {
+			current = r.Source
+			fmt.Printf("\n%s=== %s ===%s\n", utils.Cyan, current.Name(), utils.Reset)
+		}
+
+		if r.Err != nil && !isNoNews(r.Err) {
+			continue
+		}
+
+		fmt.Printf("%s%s - %s kategorisinden haberler%s:%s\n", utils.Green, r.Source.Name(), r.Category, fetchedNote(offline, r.Items), utils.Reset)
+		if len(r.Items) == 0 {
+			fmt.Println("Bu kategoride haber bulunamadı.")
+			continue
+		}
+		for _, item := range r.Items {
+			fmt.Printf("%s%s: %s", utils.Red, item.Title, utils.Reset)
+			fmt.Printf("%s%s%s\n", utils.Gray, item.URL, utils.Reset)
+		}
+	}
+
+	printFailures(results)
+}
+
+// printFailures lists the categories of a FetchAll run that failed
+func printFailures(results []sources.Result) {
+	var failed []sources.Result
+	for _, r := range results {
+		if r.Err != nil && !isNoNews(r.Err) {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%sAlınamayan kategoriler:%s\n", utils.Yellow, utils.Reset)
+	for _, r := range failed {
+		fmt.Printf("%v (%s)\n", r.Err, describeError(r.Err))
+	}
+}
+
+// allFailed reports whether every fetch of a FetchAll run failed
+func allFailed(results []sources.Result) bool {
+	for _, r := range results {
+		if r.Err == nil || isNoNews(r.Err) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeResults serializes the results of a FetchAll run in a machine-readable
+// format to stdout like writeItems. Failed categories are reported on stderr.
+func writeResults(format output.Format, feedID string, results []sources.Result) int {
+	reportFailures(results)
+	return writeItems(format, feedID, resultItems(results))
+}
+
+// reportFailures lists the failed categories of a FetchAll run on stderr
+func reportFailures(results []sources.Result) {
+	for _, r := range results {
+		if r.Err != nil && !isNoNews(r.Err) {
+			fmt.Fprintf(os.Stderr, "%v (%s)\n", r.Err, describeError(r.Err))
+		}
+	}
+}
+
+// resultItems returns the items of every successful result of a FetchAll run
+func resultItems(results []sources.Result) []sources.NewsItem {
+	var items []sources.NewsItem
+	for _, r := range results {
+		if r.Err == nil {
+			items = append(items, r.Items...)
+		}
+	}
+	return items
+}
+
+// writeItems serializes items in a machine-readable format to stdout and
+// returns the exit code. Feeds are identified by feedID, see output.FeedID.
+func writeItems(format output.Format, feedID string, items []sources.NewsItem) int {
+	var err error
+	if format == output.RSS || format == output.Atom {
+		err = output.WriteFeed(os.Stdout, format, feedID, output.NewRecords(items))
+	} else {
+		err = output.Write(os.Stdout, format, output.NewRecords(items))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// printUnsupported reports the sources that do not cover the requested
+// topic. The note goes to stderr for machine-readable formats.
+func printUnsupported(format output.Format, topic sources.Topic, unsupported []sources.NewsSource) {
+	if len(unsupported) == 0 {
+		return
+	}
+	names := make([]string, len(unsupported))
+	for i, source := range unsupported {
+		names[i] = source.Name()
+	}
+
+	msg := fmt.Sprintf("%s konusunu desteklemeyen kaynaklar: %s", topic, strings.Join(names, ", "))
+	if format != output.Text {
+		fmt.Fprintln(os.Stderr, msg)
+		return
+	}
+	fmt.Printf("\n%s%s%s\n", utils.Yellow, msg, utils.Reset)
+}
+
+// topicList lists the canonical topics for help and error messages
+func topicList() string {
+	names := make([]string, len(sources.Topics))
+	for i, topic := range sources.Topics {
+		names[i] = string(topic)
+	}
+	return strings.Join(names, ", ")
+}
+
+// printSourceList prints every registered news source with its metadata
+func printSourceList() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KİMLİK\tAD\tTÜR\tDİL\tDURUM\tADRES")
+	for _, entry := range sources.List() {
+		status := "açık"
+		if !entry.Enabled {
+			status = "kapalı"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.ID, entry.Name, entry.Type, entry.Language, status, entry.Homepage)
+	}
+	w.Flush()
+}
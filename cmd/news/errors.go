@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/snapshot"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+// describeError returns a short Turkish explanation of why a fetch failed
+func describeError(err error) string {
+	switch {
+	case errors.Is(err, snapshot.ErrNotFound):
+		return "çevrimdışı kopya yok"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "zaman aşımı"
+	case errors.Is(err, context.Canceled):
+		return "iptal edildi"
+	case errors.Is(err, sources.ErrNetwork):
+		return "bağlantı hatası"
+	case errors.Is(err, sources.ErrHTTPStatus):
+		return "sunucu hatası"
+	case errors.Is(err, sources.ErrParse):
+		return "içerik okunamadı"
+	case errors.Is(err, sources.ErrUnknownCategory):
+		return "bilinmeyen kategori"
+	default:
+		return "hata"
+	}
+}
+
+// isNoNews reports whether err only means that a category had no news,
+// as opposed to the source being broken
+func isNoNews(err error) bool {
+	return errors.Is(err, sources.ErrEmptyFeed)
+}
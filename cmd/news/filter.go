@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/filter"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+// stringList is a flag that can be given several times
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+// Set appends a value to the list
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// filterResults applies f to every successful result of a FetchAll run
+func filterResults(f *filter.Filter, results []sources.Result) {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Items = f.Apply(results[i].Items)
+		}
+	}
+}
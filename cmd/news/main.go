@@ -2,25 +2,61 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
-	"log"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/furkandogmus/HaberlerPlus/pkg/cache"
+	"github.com/furkandogmus/HaberlerPlus/pkg/cluster"
+	"github.com/furkandogmus/HaberlerPlus/pkg/config"
+	"github.com/furkandogmus/HaberlerPlus/pkg/fetch"
+	"github.com/furkandogmus/HaberlerPlus/pkg/output"
 	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/store"
+	"github.com/furkandogmus/HaberlerPlus/pkg/tui"
 	"github.com/furkandogmus/HaberlerPlus/pkg/utils"
 )
 
+// exitUsage is the exit code used for invalid user input
+const exitUsage = 2
+
 func main() {
+	os.Exit(run())
+}
+
+// run runs the program and returns its exit code, so that deferred
+// cleanups such as closing the article store happen before exiting
+func run() int {
 	showVersion := flag.Bool("v", false, "Versiyon bilgisini göster!")
 	showHelp := flag.Bool("h", false, "Yardım bilgisini göster!")
+	timeout := flag.Duration("timeout", 30*time.Second, "Haber çekme işlemi için zaman aşımı süresi")
+	sourceName := flag.String("source", "", "Haber kaynağının kimliği, adı veya numarası (örn. ntv, \"cnn türk\", 6)")
+	categoryName := flag.String("category", "", "Kategorinin adı, numarası veya konusu (örn. \"SON DAKİKA\", ekonomi, 1)")
+	fetchAll := flag.Bool("all", false, "Tüm kaynakların tüm kategorilerini aynı anda getir")
+	workers := flag.Int("workers", sources.DefaultWorkers, "-all modunda aynı anda yapılacak en fazla istek sayısı")
+	outputName := flag.String("o", "text", "Çıktı biçimi: text, json, ndjson, csv, tsv, rss veya atom")
+	configPath := flag.String("config", "", "Yapılandırma dosyası (varsayılan ~/.config/haberlerplus/config.yaml)")
+	listSources := flag.Bool("list", false, "Haber kaynaklarını kimlikleriyle birlikte listele")
+	noCache := flag.Bool("no-cache", false, "Önbelleği kullanmadan her şeyi yeniden indir")
+	cacheTTL := flag.Duration("cache-ttl", cache.DefaultTTL, "Önbellekteki içeriğin yeniden doğrulanmadan kullanılacağı süre")
+	offline := flag.Bool("offline", false, "Ağa bağlanmadan son alınan haberleri göster")
+	onlyNew := flag.Bool("new", false, "Yalnızca daha önce gösterilmemiş haberleri göster")
+	tuiMode := flag.Bool("tui", false, "Etkileşimli terminal arayüzünü aç")
+	clusterNews := flag.Bool("cluster", false, "Farklı kaynaklardaki aynı haberleri tek başlık altında topla")
+	var include, exclude stringList
+	flag.Var(&include, "include", "Yalnızca bu anahtar kelimeyi veya /düzenli ifadeyi/ içeren haberleri göster (tekrarlanabilir)")
+	flag.Var(&exclude, "exclude", "Bu anahtar kelimeyi veya /düzenli ifadeyi/ içeren haberleri gizle (tekrarlanabilir)")
 	flag.Parse()
 
 	if *showVersion {
 		fmt.Printf("HaberlerPlus Versiyon %s\n", utils.Version)
-		return
+		return 0
 	}
 	if *showHelp {
 		fmt.Println("CLI Haber Bülteni Plus")
@@ -29,83 +65,298 @@ func main() {
 		fmt.Println("Seçenekler:")
 		fmt.Println("-h  yardım bilgisini verir.")
 		fmt.Println("-v  versiyon bilgisini verir.")
-		return
+		fmt.Println("-timeout  haber çekme işlemi için zaman aşımı süresi (örn. 10s, 1m).")
+		fmt.Println("-source  haber kaynağını kimliği, adı veya numarası ile seçer (örn. -source ntv).")
+		fmt.Println("-category  kategoriyi adı veya numarası ile seçer (örn. -category \"SON DAKİKA\"). -source verilmezse")
+		fmt.Println("           konuyu destekleyen tüm kaynaklardan getirir (örn. -category ekonomi).")
+		fmt.Println("-all  tüm kaynakların tüm kategorilerini aynı anda getirir (-source ile tek kaynağa sınırlanabilir).")
+		fmt.Println("-workers  -all modunda aynı anda yapılacak en fazla istek sayısı.")
+		fmt.Println("-o  çıktı biçimini seçer: text, json, ndjson, csv, tsv, rss veya atom.")
+		fmt.Println("-config  ek haber kaynaklarının tanımlandığı yapılandırma dosyasını seçer.")
+		fmt.Println("-list  haber kaynaklarını kimlikleri, türleri ve durumlarıyla listeler.")
+		fmt.Println("-no-cache  önbelleği kullanmadan tüm sayfaları yeniden indirir.")
+		fmt.Println("-cache-ttl  önbellekteki içeriğin yeniden doğrulanmadan kullanılacağı süre (örn. 5m).")
+		fmt.Println("-offline  ağa bağlanmadan her kategorinin en son alınan haberlerini gösterir.")
+		fmt.Println("-new  yalnızca daha önce gösterilmemiş haberleri gösterir ve gizlenenlerin sayısını bildirir.")
+		fmt.Println("-tui  kaynak listesi, başlıklar ve önizleme bölmesi olan etkileşimli terminal arayüzünü açar.")
+		fmt.Println("-cluster  farklı kaynaklardaki aynı haberleri tek başlık altında toplar ve haberi veren kaynakları listeler.")
+		fmt.Println("-include  yalnızca anahtar kelimeyi veya /düzenli ifadeyi/ içeren haberleri gösterir (örn. -include dolar -include \"title:/altın|gümüş/\").")
+		fmt.Println("-exclude  anahtar kelimeyi veya /düzenli ifadeyi/ içeren haberleri gizler (örn. -exclude magazin).")
+		fmt.Println()
+		fmt.Printf("Konular: %s\n", topicList())
+		fmt.Println()
+		fmt.Println("Komutlar:")
+		fmt.Println("cache clear  önbelleği temizler.")
+		fmt.Println("search <metin>  haber arşivinde arar (örn. news search enflasyon -since 7d -source ntv).")
+		fmt.Println("serve  haberleri JSON API olarak sunan HTTP sunucusunu başlatır (örn. news serve -addr :8080).")
+		fmt.Println("read <adres>  haberin metnini sayfasından alıp gösterir (örn. news read https://www.ntv.com.tr/...).")
+		return 0
 	}
 
-	// Get all available news sources
-	allSources := sources.GetAllSources()
+	// Get all available news sources, including the ones from the config file
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return usageError("Yapılandırma dosyası okunamadı: %v", err)
+	}
+	if err := cfg.Register(); err != nil {
+		return usageError("Yapılandırma dosyası uygulanamadı: %v", err)
+	}
+	newsFilter, err := cfg.Filter(include, exclude)
+	if err != nil {
+		return usageError("Geçersiz filtre: %v", err)
+	}
 
-	// Display available news sources
-	fmt.Printf("%sHaber Kaynakları:\n", utils.Cyan)
-	for i, source := range allSources {
-		fmt.Printf("%s%d. %s%s\n", utils.Yellow, i+1, source.Name(), utils.Reset)
+	// -list needs the configured sources only, whatever the other flags say
+	if *listSources {
+		printSourceList()
+		return 0
 	}
 
-	// Get user input for news source
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("%sHaber kaynağı numarası girin: %s", utils.Cyan, utils.Reset)
-	input, err := reader.ReadString('\n')
+	// Serve feeds and pages from the disk cache when possible
+	if !*noCache {
+		if dir, err := cache.DefaultDir(); err == nil {
+			c := cache.New(dir, *cacheTTL)
+			if err := c.Prune(); err != nil {
+				fmt.Fprintf(os.Stderr, "Eski önbellek kayıtları silinemedi: %v\n", err)
+			}
+			fetch.Default = c
+		}
+	}
+
+	if flag.NArg() > 0 {
+		return runCommand(flag.Args(), commandOptions{
+			timeout: *timeout,
+			workers: *workers,
+			filter:  newsFilter,
+			offline: *offline,
+		})
+	}
+
+	format, err := output.ParseFormat(*outputName)
 	if err != nil {
-		log.Fatal(err)
+		return usageError("Geçersiz çıktı biçimi: %v", err)
+	}
+	// A category given with -all or without a source names a canonical
+	// topic, which is fetched from every source covering it
+	var topic sources.Topic
+	topicMode := false
+	if *categoryName != "" && (*fetchAll || *sourceName == "") {
+		topic, topicMode = sources.ParseTopic(*categoryName)
+		if !topicMode && *fetchAll {
+			return usageError("Geçersiz konu: %s (geçerli konular: %s)", *categoryName, topicList())
+		}
+	}
+	if format != output.Text && !*fetchAll && !topicMode && (*sourceName == "" || *categoryName == "") {
+		return usageError("-o %s için -source ve -category ya da -all parametreleri gereklidir.", format)
+	}
+	if format != output.Text || !utils.IsTerminal(os.Stdout) {
+		utils.DisableColors()
 	}
 
-	input = strings.TrimSpace(input)
-	sourceNum, err := strconv.Atoi(input)
+	// Keep the latest news of every category for offline use and every
+	// fetched item in the article store
+	wrap, err := newWrapper(*offline, *onlyNew)
 	if err != nil {
-		log.Fatal("Lütfen geçerli bir sayı girin.")
+		fmt.Fprintln(os.Stderr, err)
+		return 1
 	}
+	defer wrap.close()
+	articles := wrap.articles
+	allSources := wrap.wrapAll(sources.GetAllSources())
 
-	if sourceNum < 1 || sourceNum > len(allSources) {
-		fmt.Println("Geçersiz haber kaynağı numarası.")
-		return
+	if *tuiMode {
+		err := tui.Run(tui.Options{Sources: allSources, Store: articles, Filter: newsFilter, Timeout: *timeout, Offline: *offline})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Arayüz çalıştırılamadı: %v\n", err)
+			return 1
+		}
+		return 0
 	}
+	reader := bufio.NewReader(os.Stdin)
 
-	// Get the selected news source
-	selectedSource := allSources[sourceNum-1]
+	// Abort fetching on Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	// Display categories for the selected source
-	categories := selectedSource.Categories()
-	fmt.Printf("%s%s Kategorileri:\n", utils.Cyan, selectedSource.Name())
-	for i, cat := range categories {
-		fmt.Printf("%s%d. %s%s\n", utils.Yellow, i+1, cat, utils.Reset)
-	}
+	if *fetchAll || topicMode {
+		selected := allSources
+		feedID := output.FeedID(string(topic))
+		if *sourceName != "" {
+			source, err := sources.Find(*sourceName)
+			if err != nil {
+				return usageError("Geçersiz haber kaynağı: %v", err)
+			}
+			selected = []sources.NewsSource{wrap.wrap(source)}
+			feedID = output.FeedID(source.Name(), string(topic))
+		}
 
-	// Get user input for category
-	fmt.Printf("%sKategori numarası girin: %s", utils.Cyan, utils.Reset)
-	input, err = reader.ReadString('\n')
-	if err != nil {
-		log.Fatal(err)
+		ctx, cancel := context.WithTimeout(ctx, *timeout)
+		defer cancel()
+
+		var results []sources.Result
+		var unsupported []sources.NewsSource
+		if topicMode {
+			results, unsupported = sources.FetchTopic(ctx, selected, topic, *workers)
+		} else {
+			results = sources.FetchAll(ctx, selected, *workers)
+		}
+		filterResults(newsFilter, results)
+		hidden := 0
+		if *onlyNew {
+			hidden = hideShownResults(articles, results)
+		}
+		switch {
+		case *clusterNews && format == output.Text:
+			items := resultItems(results)
+			clusters := cluster.Group(items, cluster.DefaultThreshold)
+			fmt.Printf("%s%d haber, %d konu%s:%s\n", utils.Green, len(items), len(clusters), fetchedNote(*offline, items), utils.Reset)
+			printClusters(clusters)
+			printFailures(results)
+		case *clusterNews:
+			reportFailures(results)
+			if code := writeItems(format, feedID, representatives(cluster.Group(resultItems(results), cluster.DefaultThreshold))); code != 0 {
+				return code
+			}
+		case format == output.Text:
+			printDigest(results, *offline)
+		default:
+			if code := writeResults(format, feedID, results); code != 0 {
+				return code
+			}
+		}
+		printHidden(format, hidden)
+		printUnsupported(format, topic, unsupported)
+		if allFailed(results) {
+			return 1
+		}
+		return 0
 	}
 
-	input = strings.TrimSpace(input)
-	categoryNum, err := strconv.Atoi(input)
-	if err != nil {
-		log.Fatal("Lütfen geçerli bir sayı girin.")
+	// Select the news source either from the flag or interactively
+	var selectedSource sources.NewsSource
+	if *sourceName != "" {
+		source, err := sources.Find(*sourceName)
+		if err != nil {
+			return usageError("Geçersiz haber kaynağı: %v", err)
+		}
+		selectedSource = wrap.wrap(source)
+	} else {
+		fmt.Printf("%sHaber Kaynakları:\n", utils.Cyan)
+		for i, source := range allSources {
+			fmt.Printf("%s%d. %s%s\n", utils.Yellow, i+1, source.Name(), utils.Reset)
+		}
+
+		sourceNum, err := promptNumber(reader, "Haber kaynağı numarası girin: ")
+		if err != nil {
+			return usageError("%v", err)
+		}
+		if sourceNum < 1 || sourceNum > len(allSources) {
+			return usageError("Geçersiz haber kaynağı numarası.")
+		}
+		selectedSource = allSources[sourceNum-1]
 	}
 
-	if categoryNum < 1 || categoryNum > len(categories) {
-		fmt.Println("Geçersiz kategori numarası.")
-		return
+	// Select the category either from the flag or interactively
+	categories := selectedSource.Categories()
+	var categoryIndex int
+	if *categoryName != "" {
+		index, err := sources.FindCategory(selectedSource, *categoryName)
+		if err != nil {
+			return usageError("Geçersiz kategori: %v", err)
+		}
+		categoryIndex = index
+	} else {
+		fmt.Printf("%s%s Kategorileri:\n", utils.Cyan, selectedSource.Name())
+		for i, cat := range categories {
+			fmt.Printf("%s%d. %s%s\n", utils.Yellow, i+1, cat, utils.Reset)
+		}
+
+		categoryNum, err := promptNumber(reader, "Kategori numarası girin: ")
+		if err != nil {
+			return usageError("%v", err)
+		}
+		if categoryNum < 1 || categoryNum > len(categories) {
+			return usageError("Geçersiz kategori numarası.")
+		}
+		categoryIndex = categoryNum - 1
 	}
 
 	// Fetch news for the selected category
-	newsItems, err := selectedSource.FetchNews(categoryNum - 1)
-	if err != nil {
-		log.Fatal(err)
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	newsItems, err := selectedSource.FetchNews(ctx, categoryIndex)
+	if err != nil && !isNoNews(err) {
+		fmt.Fprintf(os.Stderr, "Haberler alınamadı (%s): %v\n", describeError(err), err)
+		return 1
+	}
+	newsItems = newsFilter.Apply(newsItems)
+	hidden := 0
+	if *onlyNew {
+		newsItems, hidden = hideShown(articles, newsItems)
+	}
+
+	var clusters []cluster.Cluster
+	if *clusterNews {
+		clusters = cluster.Group(newsItems, cluster.DefaultThreshold)
+		newsItems = representatives(clusters)
 	}
 
 	// Display the news
-	fmt.Printf("%s%s - %s kategorisinden haberler:%s\n", utils.Green, selectedSource.Name(), categories[categoryNum-1], utils.Reset)
+	if format != output.Text {
+		if code := writeItems(format, output.FeedID(selectedSource.Name(), categories[categoryIndex]), newsItems); code != 0 {
+			return code
+		}
+		printHidden(format, hidden)
+		return 0
+	}
+
+	fmt.Printf("%s%s - %s kategorisinden haberler%s:%s\n", utils.Green, selectedSource.Name(), categories[categoryIndex], fetchedNote(*offline, newsItems), utils.Reset)
 
-	if len(newsItems) == 0 {
+	if len(newsItems) == 0 && hidden > 0 {
+		fmt.Println("Bu kategoride yeni haber yok.")
+	} else if len(newsItems) == 0 {
 		fmt.Println("Bu kategoride haber bulunamadı.")
-		return
 	}
 
-	for _, item := range newsItems {
-		
+	if *clusterNews {
+		printClusters(clusters)
+	} else {
+		for _, item := range newsItems {
 			fmt.Printf("%s%s: %s", utils.Red, item.Title, utils.Reset)
 			fmt.Printf("%s%s%s\n", utils.Gray, item.URL, utils.Reset)
-		
+		}
 	}
-}
\ No newline at end of file
+	printHidden(format, hidden)
+	return 0
+}
+
+// openStore opens the article store at its default location
+func openStore() (*store.Store, error) {
+	path, err := store.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return store.Open(path)
+}
+
+// promptNumber asks the user for a number on stdin
+func promptNumber(reader *bufio.Reader, prompt string) (int, error) {
+	fmt.Printf("%s%s%s", utils.Cyan, prompt, utils.Reset)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+
+	num, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		return 0, errors.New("Lütfen geçerli bir sayı girin.")
+	}
+	return num, nil
+}
+
+// usageError reports invalid user input and returns the exit code for it
+func usageError(format string, args ...interface{}) int {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	return exitUsage
+}
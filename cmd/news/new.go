@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/output"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/store"
+	"github.com/furkandogmus/HaberlerPlus/pkg/utils"
+)
+
+// hideShown drops the items shown by earlier -new runs, marks the remaining
+// ones as shown and returns them together with the number of hidden items.
+// If the article store fails, every item is returned.
+func hideShown(articles *store.Store, items []sources.NewsItem) ([]sources.NewsItem, int) {
+	ctx := context.Background()
+	unshown, err := articles.Unshown(ctx, items)
+	if err == nil {
+		err = articles.MarkShown(ctx, unshown)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Uyarı: gösterilen haberler okunamadı: %v\n", err)
+		return items, 0
+	}
+	return unshown, len(items) - len(unshown)
+}
+
+// hideShownResults applies hideShown to every successful result of a
+// FetchAll run and returns the total number of hidden items
+func hideShownResults(articles *store.Store, results []sources.Result) int {
+	hidden := 0
+	for i := range results {
+		r := &results[i]
+		if r.Err != nil {
+			continue
+		}
+		var n int
+		r.Items, n = hideShown(articles, r.Items)
+		hidden += n
+	}
+	return hidden
+}
+
+// printHidden reports how many items -new hid. The note goes to stderr
+// for machine-readable formats so that it does not corrupt the output.
+func printHidden(format output.Format, hidden int) {
+	if hidden == 0 {
+		return
+	}
+	if format != output.Text {
+		fmt.Fprintf(os.Stderr, "%d haber daha önce gösterildiği için gizlendi.\n", hidden)
+		return
+	}
+	fmt.Printf("%s%d haber daha önce gösterildiği için gizlendi.%s\n", utils.Gray, hidden, utils.Reset)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/snapshot"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/store"
+)
+
+// wrapper decorates the news sources picked on the command line so that
+// fetched news are kept for later use
+type wrapper struct {
+	// snapshots keeps the latest news of every category, if set
+	snapshots *snapshot.Store
+	// articles keeps every fetched item, if set
+	articles *store.Store
+	// offline serves news from the snapshots instead of the network
+	offline bool
+}
+
+// newWrapper opens the snapshot and article stores. A missing article store
+// only warns unless requireStore is set.
+func newWrapper(offline, requireStore bool) (*wrapper, error) {
+	w := &wrapper{offline: offline}
+	if dir, err := snapshot.DefaultDir(); err == nil {
+		w.snapshots = snapshot.NewStore(dir)
+	} else if offline {
+		return nil, fmt.Errorf("Çevrimdışı kopyaların dizini bulunamadı: %v", err)
+	}
+	articles, err := openStore()
+	if err == nil {
+		w.articles = articles
+	} else if requireStore {
+		return nil, fmt.Errorf("Haber arşivi açılamadı: %v", err)
+	} else {
+		fmt.Fprintf(os.Stderr, "Uyarı: haber arşivi açılamadı: %v\n", err)
+	}
+	return w, nil
+}
+
+// close closes the article store
+func (w *wrapper) close() {
+	if w.articles != nil {
+		w.articles.Close()
+	}
+}
+
+// wrapAll wraps every source like wrap
+func (w *wrapper) wrapAll(all []sources.NewsSource) []sources.NewsSource {
+	wrapped := make([]sources.NewsSource, len(all))
+	for i, source := range all {
+		wrapped[i] = w.wrap(source)
+	}
+	return wrapped
+}
+
+// wrap makes source save successful fetches to the snapshot and article
+// stores, or in offline mode read its news back from the snapshots
+func (w *wrapper) wrap(source sources.NewsSource) sources.NewsSource {
+	if w.offline {
+		if w.snapshots != nil {
+			source = snapshot.Offline(w.snapshots, source)
+		}
+		return source
+	}
+	if w.articles != nil {
+		source = store.Record(w.articles, source)
+	}
+	if w.snapshots != nil {
+		source = snapshot.Record(w.snapshots, source)
+	}
+	return source
+}
+
+// fetchedNote describes when offline items were fetched, e.g.
+// " (çevrimdışı kopya, 17.10.2026 08:30)", using the oldest fetch time when
+// the items come from several snapshots; it is empty for fresh news
+func fetchedNote(offline bool, items []sources.NewsItem) string {
+	var oldest time.Time
+	for _, item := range items {
+		if !item.FetchedAt.IsZero() && (oldest.IsZero() || item.FetchedAt.Before(oldest)) {
+			oldest = item.FetchedAt
+		}
+	}
+	if !offline || oldest.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(" (çevrimdışı kopya, %s)", oldest.Local().Format("02.01.2006 15:04"))
+}
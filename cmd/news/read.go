@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/article"
+	"github.com/furkandogmus/HaberlerPlus/pkg/fetch"
+	"github.com/furkandogmus/HaberlerPlus/pkg/output"
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+	"github.com/furkandogmus/HaberlerPlus/pkg/utils"
+)
+
+// maxReadWidth caps the line length of articles on wide terminals
+const maxReadWidth = 100
+
+// runRead downloads a news article and prints its text, e.g.
+// news read https://www.ntv.com.tr/ekonomi/...
+func runRead(args []string, timeout time.Duration) int {
+	fs := flag.NewFlagSet("read", flag.ExitOnError)
+	width := fs.Int("width", 0, "Satır genişliği (varsayılan: terminal genişliği)")
+	outputName := fs.String("o", "text", "Çıktı biçimi: text veya json")
+	urls := parseInterspersed(fs, args)
+	if len(urls) != 1 || !strings.HasPrefix(urls[0], "http") {
+		return usageError("Kullanım: news read <adres> [-width 80]")
+	}
+
+	format, err := output.ParseFormat(*outputName)
+	if err != nil || (format != output.Text && format != output.JSON) {
+		return usageError("Geçersiz çıktı biçimi: %s (text veya json olmalı)", *outputName)
+	}
+	if format != output.Text || !utils.IsTerminal(os.Stdout) {
+		utils.DisableColors()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	a, err := article.Fetch(ctx, fetch.Default, urls[0])
+	if err != nil {
+		if errors.Is(err, article.ErrNoContent) {
+			fmt.Fprintf(os.Stderr, "Sayfada haber metni bulunamadı: %s\n", urls[0])
+		} else {
+			fmt.Fprintf(os.Stderr, "Haber alınamadı: %v\n", err)
+		}
+		return 1
+	}
+
+	if format == output.JSON {
+		if err := writeArticleJSON(a); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	}
+	printArticle(a, readWidth(*width))
+	return 0
+}
+
+// readWidth returns the line length to wrap articles at
+func readWidth(width int) int {
+	if width > 0 {
+		return width
+	}
+	if w, _, err := term.GetSize(os.Stdout.Fd()); err == nil && w > 0 {
+		return min(w, maxReadWidth)
+	}
+	return 80
+}
+
+// printArticle prints an article wrapped to width
+func printArticle(a *article.Article, width int) {
+	for _, line := range text.Wrap(a.Title, width) {
+		fmt.Printf("%s%s%s\n", utils.Red, line, utils.Reset)
+	}
+
+	var details []string
+	if a.Byline != "" {
+		details = append(details, a.Byline)
+	}
+	if !a.Published.IsZero() {
+		details = append(details, a.Published.Local().Format("02.01.2006 15:04"))
+	}
+	details = append(details, a.URL)
+	fmt.Printf("%s%s%s\n", utils.Gray, strings.Join(details, " - "), utils.Reset)
+
+	for _, paragraph := range a.Paragraphs {
+		fmt.Println()
+		for _, line := range text.Wrap(paragraph, width) {
+			fmt.Println(line)
+		}
+	}
+}
+
+// writeArticleJSON prints an article as JSON, with the field naming of the
+// news item records
+func writeArticleJSON(a *article.Article) error {
+	publishedAt := ""
+	if !a.Published.IsZero() {
+		publishedAt = a.Published.Format(time.RFC3339)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(struct {
+		URL         string   `json:"url"`
+		Title       string   `json:"title"`
+		Byline      string   `json:"byline"`
+		ImageURL    string   `json:"image_url"`
+		PublishedAt string   `json:"published_at"`
+		Paragraphs  []string `json:"paragraphs"`
+	}{a.URL, a.Title, a.Byline, a.ImageURL, publishedAt, a.Paragraphs})
+}
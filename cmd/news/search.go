@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/output"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/store"
+	"github.com/furkandogmus/HaberlerPlus/pkg/utils"
+)
+
+// runSearch searches the article store, e.g.
+// news search "enflasyon" -since 7d -source ntv
+func runSearch(args []string) int {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	since := fs.String("since", "", "Yalnızca bu süre içinde görülen haberleri ara (örn. 12h, 7d, 2w, 2026-10-01)")
+	sourceName := fs.String("source", "", "Yalnızca bu haber kaynağında ara")
+	categoryName := fs.String("category", "", "Yalnızca bu kategoride ara")
+	limit := fs.Int("limit", 20, "En fazla gösterilecek sonuç sayısı (0 sınırsız)")
+	outputName := fs.String("o", "text", "Çıktı biçimi: text, json, ndjson, csv, tsv, rss veya atom")
+	words := parseInterspersed(fs, args)
+	if len(words) == 0 {
+		return usageError("Kullanım: news search <metin> [-since 7d] [-source ntv] [-category ekonomi]")
+	}
+
+	format, err := output.ParseFormat(*outputName)
+	if err != nil {
+		return usageError("Geçersiz çıktı biçimi: %v", err)
+	}
+	if format != output.Text || !utils.IsTerminal(os.Stdout) {
+		utils.DisableColors()
+	}
+
+	query := store.Query{Text: strings.Join(words, " "), Category: *categoryName, Limit: *limit}
+	if *since != "" {
+		t, err := parseSince(*since, time.Now())
+		if err != nil {
+			return usageError("Geçersiz -since değeri: %v", err)
+		}
+		query.Since = t
+	}
+	if *sourceName != "" {
+		query.Source = *sourceName
+		if source, err := sources.Find(*sourceName); err == nil {
+			query.Source = source.Name()
+		}
+	}
+
+	articles, err := openStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Haber arşivi açılamadı: %v\n", err)
+		return 1
+	}
+	defer articles.Close()
+
+	matches, err := articles.Search(context.Background(), query)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Arama yapılamadı: %v\n", err)
+		return 1
+	}
+
+	if format != output.Text {
+		items := make([]sources.NewsItem, len(matches))
+		for i, m := range matches {
+			items[i] = m.NewsItem
+		}
+		return writeItems(format, output.FeedID("search", query.Text, query.Source, query.Category), items)
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("Aramayla eşleşen haber bulunamadı.")
+		return 0
+	}
+	for _, m := range matches {
+		fmt.Printf("%s%s: %s", utils.Red, m.Title, utils.Reset)
+		fmt.Printf("%s%s%s\n", utils.Gray, m.URL, utils.Reset)
+		fmt.Printf("%s  %s - %s, %s%s\n", utils.Gray, m.Source, m.Category, m.Time().Local().Format("02.01.2006 15:04"), utils.Reset)
+	}
+	return 0
+}
+
+// parseInterspersed parses flags that may appear before, between or after
+// the positional arguments and returns the positional arguments. A "--"
+// ends the flags: every argument after it is positional, e.g. "-5".
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		rest := fs.Args()
+		// Parse consumes the "--" it stops at
+		if consumed := args[:len(args)-len(rest)]; len(consumed) > 0 && consumed[len(consumed)-1] == "--" {
+			return append(positional, rest...)
+		}
+		args = rest
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseSince turns a -since value into a point in time. It accepts Go
+// durations (90m, 12h), days and weeks (7d, 2w) and dates (2026-10-01).
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if n := len(value) - 1; n > 0 && (value[n] == 'd' || value[n] == 'w') {
+		count, err := strconv.Atoi(value[:n])
+		if err != nil || count < 0 {
+			return time.Time{}, fmt.Errorf("%q bir süre değil", value)
+		}
+		days := count
+		if value[n] == 'w' {
+			days *= 7
+		}
+		return now.AddDate(0, 0, -days), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("%q bir süre değil", value)
+	}
+	return now.Add(-d), nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.Local)
+	for _, tt := range []struct {
+		value string
+		want  time.Time
+	}{
+		{"90m", now.Add(-90 * time.Minute)},
+		{"12h", now.Add(-12 * time.Hour)},
+		{"0s", now},
+		{"7d", time.Date(2026, 10, 10, 12, 0, 0, 0, time.Local)},
+		{"2w", time.Date(2026, 10, 3, 12, 0, 0, 0, time.Local)},
+		{"0d", now},
+		{"2026-10-01", time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)},
+	} {
+		got, err := parseSince(tt.value, now)
+		if err != nil {
+			t.Errorf("parseSince(%q): %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "d", "w", "-1d", "-2h", "7 d", "yedi gün", "2026-13-01", "17.10.2026"} {
+		if got, err := parseSince(value, now); err == nil {
+			t.Errorf("parseSince(%q) = %v, want error", value, got)
+		}
+	}
+}
+
+func TestParseInterspersed(t *testing.T) {
+	for _, tt := range []struct {
+		args  []string
+		want  []string
+		limit int
+	}{
+		{[]string{"enflasyon"}, []string{"enflasyon"}, 20},
+		{[]string{"-limit", "5", "merkez", "bankası"}, []string{"merkez", "bankası"}, 5},
+		{[]string{"merkez", "-limit", "5", "bankası"}, []string{"merkez", "bankası"}, 5},
+		{[]string{"merkez", "bankası", "-limit=5"}, []string{"merkez", "bankası"}, 5},
+		{[]string{"--", "-limit", "5"}, []string{"-limit", "5"}, 20},
+		{[]string{"deprem", "-limit", "5", "--", "-6", "--", "büyüklüğünde"}, []string{"deprem", "-6", "--", "büyüklüğünde"}, 5},
+		{[]string{"deprem", "--"}, []string{"deprem"}, 20},
+		{nil, nil, 20},
+	} {
+		fs := flag.NewFlagSet("search", flag.ContinueOnError)
+		limit := fs.Int("limit", 20, "")
+		got := parseInterspersed(fs, tt.args)
+		if !reflect.DeepEqual(got, tt.want) || *limit != tt.limit {
+			t.Errorf("parseInterspersed(%q) = %q, -limit %d, want %q, -limit %d", tt.args, got, *limit, tt.want, tt.limit)
+		}
+	}
+}
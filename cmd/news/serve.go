@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/server"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+// runServe serves the news as a JSON API until interrupted, e.g.
+// news serve -addr :8080
+func runServe(args []string, opts commandOptions) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "Sunucunun dinleyeceği adres")
+	ttl := fs.Duration("ttl", server.DefaultTTL, "Haberlerin kaynaklardan yeniden alınmadan sunulacağı süre")
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		return usageError("Kullanım: news serve [-addr :8080] [-ttl 2m]")
+	}
+
+	wrap, err := newWrapper(opts.offline, false)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer wrap.close()
+
+	srv := server.New(server.Options{
+		Sources: wrap.wrapAll(sources.GetAllSources()),
+		Filter:  opts.filter,
+		TTL:     *ttl,
+		Timeout: opts.timeout,
+		Workers: opts.workers,
+	})
+
+	// Stop accepting requests on Ctrl+C or when the service is stopped
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintf(os.Stderr, "Sunucu %s adresinde çalışıyor.\n", *addr)
+	if err := srv.ListenAndServe(ctx, *addr); err != nil {
+		fmt.Fprintf(os.Stderr, "Sunucu çalıştırılamadı: %v\n", err)
+		return 1
+	}
+	fmt.Fprintln(os.Stderr, "Sunucu durduruldu.")
+	return 0
+}
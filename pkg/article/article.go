@@ -0,0 +1,141 @@
+// Package article extracts the readable text of a news article from its
+// web page. Known sites are read with their own CSS selectors; other pages,
+// and known ones whose markup changed, fall back to readability heuristics
+// that look for the block of the page with the most paragraph text.
+package article
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/feed"
+	"github.com/furkandogmus/HaberlerPlus/pkg/fetch"
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// ErrNoContent is returned when no article text could be found on a page
+var ErrNoContent = errors.New("no article text found")
+
+// Article is the readable content of a news article
+type Article struct {
+	URL      string
+	Title    string
+	Byline   string
+	ImageURL string
+	// Published is the publication time; it is zero when unknown
+	Published time.Time
+	// Paragraphs is the plain text of the article, one entry per paragraph
+	Paragraphs []string
+}
+
+// Text returns the paragraphs of the article separated by blank lines
+func (a *Article) Text() string {
+	return strings.Join(a.Paragraphs, "\n\n")
+}
+
+// Fetch downloads the page at rawURL with f, or fetch.Default when f is
+// nil, and extracts its article
+func Fetch(ctx context.Context, f fetch.Fetcher, rawURL string) (*Article, error) {
+	if f == nil {
+		f = fetch.Default
+	}
+	body, err := f.Fetch(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(body, rawURL)
+}
+
+// Parse extracts the article from an HTML page downloaded from rawURL
+func Parse(body []byte, rawURL string) (*Article, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rawURL, err)
+	}
+	base, _ := url.Parse(rawURL)
+	return Extract(doc, base)
+}
+
+// Extract extracts the article from a parsed page; base is the page URL
+// used to pick site selectors and resolve image links, and may be nil
+func Extract(doc *goquery.Document, base *url.URL) (*Article, error) {
+	a := &Article{
+		Title:     pageTitle(doc),
+		Byline:    meta(doc, "author", "article:author"),
+		ImageURL:  meta(doc, "og:image", "twitter:image"),
+		Published: pageTime(doc),
+	}
+	if base != nil {
+		a.URL = base.String()
+		if a.ImageURL != "" {
+			if ref, err := base.Parse(a.ImageURL); err == nil {
+				a.ImageURL = ref.String()
+			}
+		}
+	}
+
+	removeClutter(doc.Selection)
+
+	var site *Site
+	if base != nil {
+		site = siteFor(base.Hostname())
+	}
+	if site != nil {
+		a.Paragraphs = site.paragraphs(doc)
+	}
+	if len(a.Paragraphs) == 0 {
+		if body := readableNode(doc.Selection); body != nil {
+			a.Paragraphs = paragraphs(body)
+		}
+	}
+	if len(a.Paragraphs) == 0 {
+		return nil, ErrNoContent
+	}
+	return a, nil
+}
+
+// pageTitle returns the headline of the page
+func pageTitle(doc *goquery.Document) string {
+	if title := meta(doc, "og:title", "twitter:title"); title != "" {
+		return title
+	}
+	if h1 := text.Clean(doc.Find("h1").First().Text()); h1 != "" {
+		return h1
+	}
+	return text.Clean(doc.Find("title").First().Text())
+}
+
+// pageTime returns the publication time declared in the page
+func pageTime(doc *goquery.Document) time.Time {
+	candidates := []string{meta(doc, "article:published_time", "datePublished", "pubdate", "date")}
+	if datetime, ok := doc.Find("time[datetime]").First().Attr("datetime"); ok {
+		candidates = append(candidates, datetime)
+	}
+	for _, value := range candidates {
+		if t, err := feed.ParseDate(value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// meta returns the content of the first <meta> tag whose property, name or
+// itemprop is one of names
+func meta(doc *goquery.Document, names ...string) string {
+	for _, name := range names {
+		for _, attr := range []string{"property", "name", "itemprop"} {
+			content, ok := doc.Find(fmt.Sprintf("meta[%s=%q]", attr, name)).First().Attr("content")
+			if content = text.Clean(content); ok && content != "" {
+				return content
+			}
+		}
+	}
+	return ""
+}
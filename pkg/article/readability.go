@@ -0,0 +1,143 @@
+package article
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// clutter lists elements that never contain article text
+const clutter = "script, style, noscript, iframe, form, button, nav, aside, footer, header, svg, template"
+
+// Class and id patterns that make an element more or less likely to hold
+// the article, in English and Turkish
+var (
+	negativeHint = regexp.MustCompile(`(?i)comment|yorum|footer|sidebar|related|ilgili|share|paylas|social|sosyal|promo|reklam|advert|banner|menu|breadcrumb|tags|etiket|newsletter|popup|cookie|widget|gallery|galeri`)
+	positiveHint = regexp.MustCompile(`(?i)article|haber|body|content|icerik|entry|main|post|story|text|metin|detail|detay`)
+)
+
+// minParagraphLength is the shortest text considered a paragraph when
+// scoring candidates
+const minParagraphLength = 25
+
+// removeClutter drops the elements that never contain article text
+func removeClutter(s *goquery.Selection) {
+	s.Find(clutter).Remove()
+}
+
+// readableNode returns the element most likely to contain the article: the
+// one whose paragraphs score highest, where longer paragraphs with more
+// commas score more and paragraphs also count half for their grandparent.
+func readableNode(root *goquery.Selection) *goquery.Selection {
+	scores := make(map[*html.Node]float64)
+	nodes := make(map[*html.Node]*goquery.Selection)
+
+	root.Find("p, pre, blockquote").Each(func(_ int, p *goquery.Selection) {
+		content := text.CollapseSpace(p.Text())
+		length := utf8.RuneCountInString(content)
+		if length < minParagraphLength {
+			return
+		}
+		score := 1 + float64(strings.Count(content, ",")) + min(float64(length)/100, 3)
+
+		parent := p.Parent()
+		if parent.Length() == 0 {
+			return
+		}
+		add := func(s *goquery.Selection, weight float64) {
+			node := s.Get(0)
+			if _, ok := scores[node]; !ok {
+				scores[node] = hintScore(s)
+				nodes[node] = s
+			}
+			scores[node] += score * weight
+		}
+		add(parent, 1)
+		if grandparent := parent.Parent(); grandparent.Length() > 0 {
+			add(grandparent, 0.5)
+		}
+	})
+
+	var best *goquery.Selection
+	bestScore := 0.0
+	for node, score := range scores {
+		score *= 1 - linkDensity(nodes[node])
+		if score > bestScore {
+			best, bestScore = nodes[node], score
+		}
+	}
+	return best
+}
+
+// hintScore rates an element by its class and id
+func hintScore(s *goquery.Selection) float64 {
+	hints := s.AttrOr("class", "") + " " + s.AttrOr("id", "")
+	score := 0.0
+	if negativeHint.MatchString(hints) {
+		score -= 25
+	}
+	if positiveHint.MatchString(hints) {
+		score += 25
+	}
+	switch goquery.NodeName(s) {
+	case "article":
+		score += 10
+	case "div", "section", "main":
+		score += 5
+	}
+	return score
+}
+
+// linkDensity returns the share of the text of s that is inside links
+func linkDensity(s *goquery.Selection) float64 {
+	total := utf8.RuneCountInString(text.CollapseSpace(s.Text()))
+	if total == 0 {
+		return 0
+	}
+	links := 0
+	s.Find("a").Each(func(_ int, a *goquery.Selection) {
+		links += utf8.RuneCountInString(text.CollapseSpace(a.Text()))
+	})
+	return float64(links) / float64(total)
+}
+
+// paragraphs returns the text blocks of an article body, skipping empty
+// ones, link lists and blocks inside elements hinting at clutter
+func paragraphs(body *goquery.Selection) []string {
+	var result []string
+	body.Find("p, h2, h3, h4, blockquote, pre, li").Each(func(_ int, s *goquery.Selection) {
+		// Nested blocks are read through their outermost block within body;
+		// body itself may be one of them, e.g. a blockquote
+		ancestors := s.ParentsUntilSelection(body)
+		if ancestors.Filter("p, blockquote, pre, li").Length() > 0 {
+			return
+		}
+		if hinted := ancestors.FilterFunction(func(_ int, p *goquery.Selection) bool {
+			return negativeHint.MatchString(p.AttrOr("class", "") + " " + p.AttrOr("id", ""))
+		}); hinted.Length() > 0 {
+			return
+		}
+		if goquery.NodeName(s) == "li" && linkDensity(s) > 0.5 {
+			return
+		}
+
+		content := text.Clean(s.Text())
+		if content == "" || linkDensity(s) > 0.8 {
+			return
+		}
+		result = append(result, content)
+	})
+
+	// Pages without paragraph markup keep their text directly in the body
+	if len(result) == 0 {
+		if content := text.Clean(body.Text()); content != "" {
+			result = append(result, content)
+		}
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+package article
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Site describes where the article text is on the pages of a news site
+type Site struct {
+	// Host is the domain of the site; subdomains match as well
+	Host string
+	// Body selects the element containing the article text; the first
+	// selector matching an element is used
+	Body []string
+	// Remove selects elements inside the body to drop, such as inline
+	// "related news" boxes
+	Remove string
+}
+
+// Sites lists the selectors of the built-in news sources. Pages on other
+// sites, or pages where none of the selectors match, are read with the
+// readability heuristics.
+var Sites = []Site{
+	{Host: "gzt.com", Body: []string{"[itemprop=articleBody]", ".article-content", "article"}},
+	{Host: "hurriyet.com.tr", Body: []string{".news-content", "[itemprop=articleBody]"}, Remove: ".news-content__inf, .related-news"},
+	{Host: "sozcu.com.tr", Body: []string{".article-body", "[itemprop=articleBody]"}},
+	{Host: "milliyet.com.tr", Body: []string{".news-content", "[itemprop=articleBody]"}, Remove: ".related-news"},
+	{Host: "haberler.com", Body: []string{".haber_metni", "[itemprop=articleBody]"}},
+	{Host: "cnnturk.com", Body: []string{".detail-content", "[itemprop=articleBody]"}},
+	{Host: "ntv.com.tr", Body: []string{".category-detail-content-inner", "[itemprop=articleBody]"}},
+	{Host: "haberturk.com", Body: []string{".cms-container", "[itemprop=articleBody]"}},
+}
+
+// siteFor returns the site rules for a host, or nil if there are none
+func siteFor(host string) *Site {
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	for i, site := range Sites {
+		if host == site.Host || strings.HasSuffix(host, "."+site.Host) {
+			return &Sites[i]
+		}
+	}
+	return nil
+}
+
+// paragraphs returns the article text using the site selectors
+func (s *Site) paragraphs(doc *goquery.Document) []string {
+	for _, selector := range s.Body {
+		body := doc.Find(selector).First()
+		if body.Length() == 0 {
+			continue
+		}
+		if s.Remove != "" {
+			body.Find(s.Remove).Remove()
+		}
+		if result := paragraphs(body); len(result) > 0 {
+			return result
+		}
+	}
+	return nil
+}
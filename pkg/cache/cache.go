@@ -0,0 +1,250 @@
+// Package cache implements an on-disk HTTP cache for feeds and pages. Bodies
+// are stored per URL together with their ETag and Last-Modified validators so
+// that stale entries can be revalidated with conditional requests.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/fetch"
+)
+
+// DefaultTTL is how long a cached response is served without revalidation
+const DefaultTTL = 5 * time.Minute
+
+// DefaultMaxAge is how long an entry that is neither fetched nor
+// revalidated is kept on disk
+const DefaultMaxAge = 7 * 24 * time.Hour
+
+// tmpPrefix starts the names of entries being written
+const tmpPrefix = ".tmp-"
+
+// Cache is a fetch.Fetcher that keeps responses on disk
+type Cache struct {
+	// Dir is the directory the entries are stored in
+	Dir string
+	// TTL is how long an entry is served without contacting the server
+	TTL time.Duration
+	// Client performs the requests for missing and stale entries
+	Client *fetch.Client
+	// MaxAge is how long Prune keeps entries that were not stored again;
+	// entries are kept forever when it is 0
+	MaxAge time.Duration
+	// ErrorLog receives errors writing entries, log.Default() when nil
+	ErrorLog *log.Logger
+}
+
+// entry is a cached response as stored on disk
+type entry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	Body         []byte    `json:"body"`
+}
+
+// DefaultDir returns the default cache location,
+// e.g. ~/.cache/haberlerplus/http on Linux.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "haberlerplus", "http"), nil
+}
+
+// New creates a Cache in dir using a default fetch.Client
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, TTL: ttl, Client: fetch.New(), MaxAge: DefaultMaxAge}
+}
+
+// revalidateKey is the context key set by Revalidate
+type revalidateKey struct{}
+
+// Revalidate returns a context that makes fetches with it revalidate
+// cached entries with the server even while they are fresh, e.g. for an
+// explicit refresh. Unchanged content still costs only a conditional request.
+func Revalidate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, revalidateKey{}, true)
+}
+
+// Fetch returns the body of url. Fresh entries are served from disk, stale
+// ones, and all of them when ctx comes from Revalidate, are revalidated with
+// a conditional request. When the server cannot be reached or fails, the
+// cached body is served regardless of its age.
+func (c *Cache) Fetch(ctx context.Context, url string) ([]byte, error) {
+	cached, _ := c.load(url)
+	revalidate, _ := ctx.Value(revalidateKey{}).(bool)
+	if cached != nil && !revalidate && time.Since(cached.FetchedAt) < c.TTL {
+		return cached.Body, nil
+	}
+
+	header := http.Header{}
+	if cached != nil {
+		if cached.ETag != "" {
+			header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := c.client().Get(ctx, url, header)
+	switch {
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests:
+		if cached != nil {
+			return cached.Body, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, &fetch.StatusError{URL: url, StatusCode: resp.StatusCode}
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		cached.FetchedAt = time.Now()
+		c.store(cached)
+		return cached.Body, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, &fetch.StatusError{URL: url, StatusCode: resp.StatusCode}
+	}
+
+	c.store(&entry{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now(),
+		Body:         resp.Body,
+	})
+	return resp.Body, nil
+}
+
+// Clear removes every cached entry
+func (c *Cache) Clear() error {
+	err := os.RemoveAll(c.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Prune removes the entries last stored more than MaxAge ago, along with
+// temporary files left behind by interrupted writes
+func (c *Cache) Prune() error {
+	files, err := os.ReadDir(c.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, file := range files {
+		name := file.Name()
+		var maxAge time.Duration
+		switch {
+		case strings.HasPrefix(name, tmpPrefix):
+			maxAge = time.Hour
+		case strings.HasSuffix(name, ".json") && c.MaxAge > 0:
+			maxAge = c.MaxAge
+		default:
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if time.Since(info.ModTime()) > maxAge {
+			if err := os.Remove(filepath.Join(c.Dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// client returns the client used for requests
+func (c *Cache) client() *fetch.Client {
+	if c.Client == nil {
+		c.Client = fetch.New()
+	}
+	return c.Client
+}
+
+// path returns the file an entry for url is stored in
+func (c *Cache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load reads the entry for url, returning nil when there is none
+func (c *Cache) load(url string) (*entry, error) {
+	data, err := os.ReadFile(c.path(url))
+	if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	if e.URL != url {
+		return nil, errors.New("cache entry does not match url")
+	}
+	return &e, nil
+}
+
+// store writes an entry to disk. Failures are logged but do not fail the
+// fetch since the cache is only an optimization.
+func (c *Cache) store(e *entry) {
+	if err := c.write(e); err != nil {
+		c.errorLog().Printf("cache: storing %s: %v", e.URL, err)
+	}
+}
+
+// write writes an entry to disk
+func (c *Cache) write(e *entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see partial entries
+	tmp, err := os.CreateTemp(c.Dir, tmpPrefix+"*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(e.URL))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// errorLog returns the logger for write errors
+func (c *Cache) errorLog() *log.Logger {
+	if c.ErrorLog == nil {
+		return log.Default()
+	}
+	return c.ErrorLog
+}
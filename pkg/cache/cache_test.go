@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		name string
+		age  time.Duration
+	}{
+		{"fresh.json", time.Hour},
+		{"old.json", 8 * 24 * time.Hour},
+		{tmpPrefix + "fresh", time.Minute},
+		{tmpPrefix + "old", 2 * time.Hour},
+		{"notes.txt", 30 * 24 * time.Hour},
+	} {
+		path := filepath.Join(dir, tt.name)
+		if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		modified := time.Now().Add(-tt.age)
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := New(dir, DefaultTTL).Prune(); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	for _, tt := range []struct {
+		name string
+		kept bool
+	}{
+		{"fresh.json", true},
+		{"old.json", false},
+		{tmpPrefix + "fresh", true},
+		{tmpPrefix + "old", false},
+		{"notes.txt", true},
+	} {
+		_, err := os.Stat(filepath.Join(dir, tt.name))
+		if kept := err == nil; kept != tt.kept {
+			t.Errorf("Prune kept %s = %v, want %v", tt.name, kept, tt.kept)
+		}
+	}
+
+	if err := New(filepath.Join(dir, "missing"), DefaultTTL).Prune(); err != nil {
+		t.Errorf("Prune of a missing directory: %v", err)
+	}
+}
+
+func TestStoreLogsErrors(t *testing.T) {
+	// A file where the cache directory should be makes every write fail
+	dir := filepath.Join(t.TempDir(), "http")
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var logged bytes.Buffer
+	c := New(dir, DefaultTTL)
+	c.ErrorLog = log.New(&logged, "", 0)
+
+	c.store(&entry{URL: "https://example.com/rss", FetchedAt: time.Now()})
+	if !strings.Contains(logged.String(), "https://example.com/rss") {
+		t.Errorf("store logged %q, want an error about the entry", logged.String())
+	}
+}
@@ -0,0 +1,159 @@
+// Package cluster groups news items from different outlets that report the
+// same story. Titles are compared as sets of word stems: two items end up in
+// the same cluster when their titles share at least three stems and the
+// Jaccard similarity of the sets, the shared stems over all distinct ones,
+// reaches a threshold, or when they link to the same URL.
+package cluster
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// DefaultThreshold is the title similarity above which two items are
+// considered the same story
+const DefaultThreshold = 0.4
+
+// stemLength is the number of letters words are cut to before comparing
+// them, a crude stemmer that ignores most Turkish suffixes
+const stemLength = 5
+
+// minCommon is the number of stems two titles must share at least, so
+// that short titles such as "Dolar rekor kırdı" and "Euro rekor kırdı"
+// are not grouped
+const minCommon = 3
+
+// Cluster is a group of items reporting the same story
+type Cluster struct {
+	// Items are the grouped items; the first one is the representative
+	Items []sources.NewsItem
+}
+
+// Representative returns the item shown for the whole cluster
+func (c Cluster) Representative() sources.NewsItem {
+	return c.Items[0]
+}
+
+// Sources returns the names of the outlets in the cluster without duplicates
+func (c Cluster) Sources() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, item := range c.Items {
+		if !seen[item.Source] {
+			seen[item.Source] = true
+			names = append(names, item.Source)
+		}
+	}
+	return names
+}
+
+// Group clusters items whose titles are at least threshold similar, or
+// which share a URL. Clusters are returned in the order their first item
+// appears in items. The representative of a cluster is its earliest
+// published item, which is most likely the original report.
+//
+// Items are only compared with the earlier items sharing a stem or the URL
+// with them, which are looked up in indexes instead of scanning all groups.
+func Group(items []sources.NewsItem, threshold float64) []Cluster {
+	type member struct {
+		stems map[string]struct{}
+		group int
+	}
+
+	var (
+		members []member
+		groups  [][]int
+		byStem  = make(map[string][]int)
+		byURL   = make(map[string][]int)
+	)
+	for i, item := range items {
+		m := member{stems: Stems(item.Title), group: -1}
+
+		shared := make(map[int]int)
+		for stem := range m.stems {
+			for _, j := range byStem[stem] {
+				shared[j]++
+			}
+		}
+
+		bestScore := 0.0
+		consider := func(g int, score float64) {
+			// Ties go to the earliest group so that the result does not
+			// depend on map iteration order
+			if score >= threshold && (score > bestScore || score == bestScore && g < m.group) {
+				m.group, bestScore = g, score
+			}
+		}
+		for j, n := range shared {
+			if n >= minCommon {
+				consider(members[j].group, jaccard(n, len(m.stems), len(members[j].stems)))
+			}
+		}
+		for _, j := range byURL[item.URL] {
+			consider(members[j].group, 1)
+		}
+
+		if m.group < 0 {
+			m.group = len(groups)
+			groups = append(groups, nil)
+		}
+		groups[m.group] = append(groups[m.group], i)
+		members = append(members, m)
+		for stem := range m.stems {
+			byStem[stem] = append(byStem[stem], i)
+		}
+		if item.URL != "" {
+			byURL[item.URL] = append(byURL[item.URL], i)
+		}
+	}
+
+	clusters := make([]Cluster, len(groups))
+	for i, group := range groups {
+		first := group[0]
+		for _, j := range group {
+			if published := items[j].PublishedAt; !published.IsZero() &&
+				(items[first].PublishedAt.IsZero() || published.Before(items[first].PublishedAt)) {
+				first = j
+			}
+		}
+
+		c := Cluster{Items: []sources.NewsItem{items[first]}}
+		for _, j := range group {
+			if j != first {
+				c.Items = append(c.Items, items[j])
+			}
+		}
+		clusters[i] = c
+	}
+	return clusters
+}
+
+// Stems returns the set of word stems of a title: its words after folding
+// case and diacritics and splitting at punctuation, cut to their first
+// letters
+func Stems(title string) map[string]struct{} {
+	set := make(map[string]struct{})
+	words := strings.FieldsFunc(text.Fold(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if runes := []rune(word); len(runes) > stemLength {
+			word = string(runes[:stemLength])
+		}
+		set[word] = struct{}{}
+	}
+	return set
+}
+
+// jaccard returns the Jaccard similarity of two sets of sizes a and b
+// sharing shared elements; it is 0 for two empty sets
+func jaccard(shared, a, b int) float64 {
+	union := a + b - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
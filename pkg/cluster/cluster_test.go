@@ -0,0 +1,138 @@
+package cluster
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+func TestStems(t *testing.T) {
+	for _, tt := range []struct {
+		title string
+		want  []string
+	}{
+		{"Merkez Bankası faizi sabit tuttu", []string{"merke", "banka", "faizi", "sabit", "tuttu"}},
+		{"İSTANBUL'da yağış: 3 ilçe su altında", []string{"istan", "da", "yagis", "3", "ilce", "su", "altin"}},
+		{"Dolar, dolar, DOLAR!", []string{"dolar"}},
+		{"", nil},
+	} {
+		var got []string
+		for stem := range Stems(tt.title) {
+			got = append(got, stem)
+		}
+		if !sameSet(got, tt.want) {
+			t.Errorf("Stems(%q) = %v, want %v", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestGroup(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		items     []sources.NewsItem
+		threshold float64
+		want      [][]string
+	}{
+		{
+			name: "similar titles",
+			items: items(
+				"Merkez Bankası faizi sabit tuttu",
+				"Merkez Bankası faizi değiştirmedi",
+				"Fenerbahçe deplasmanda kazandı",
+			),
+			threshold: DefaultThreshold,
+			want: [][]string{
+				{"Merkez Bankası faizi sabit tuttu", "Merkez Bankası faizi değiştirmedi"},
+				{"Fenerbahçe deplasmanda kazandı"},
+			},
+		},
+		{
+			// 3 of 6 distinct stems are shared, a similarity of exactly 0.5
+			name:      "similarity equal to the threshold",
+			items:     items("Merkez Bankası faizi sabit tuttu", "Merkez Bankası faizi değiştirmedi"),
+			threshold: 0.5,
+			want:      [][]string{{"Merkez Bankası faizi sabit tuttu", "Merkez Bankası faizi değiştirmedi"}},
+		},
+		{
+			name:      "similarity below the threshold",
+			items:     items("Merkez Bankası faizi sabit tuttu", "Merkez Bankası faizi değiştirmedi"),
+			threshold: 0.51,
+			want:      [][]string{{"Merkez Bankası faizi sabit tuttu"}, {"Merkez Bankası faizi değiştirmedi"}},
+		},
+		{
+			// Identical apart from one word, but only two stems are shared
+			name:      "fewer than three common stems",
+			items:     items("Dolar rekor kırdı", "Euro rekor kırdı"),
+			threshold: DefaultThreshold,
+			want:      [][]string{{"Dolar rekor kırdı"}, {"Euro rekor kırdı"}},
+		},
+		{
+			name: "same URL",
+			items: []sources.NewsItem{
+				{Title: "Son dakika: deprem", URL: "https://example.com/deprem"},
+				{Title: "Marmara'da korkutan sarsıntı", URL: "https://example.com/deprem"},
+			},
+			threshold: DefaultThreshold,
+			want:      [][]string{{"Son dakika: deprem", "Marmara'da korkutan sarsıntı"}},
+		},
+		{
+			name: "earliest published item represents the cluster",
+			items: []sources.NewsItem{
+				{Title: "Merkez Bankası faizi sabit tuttu", URL: "https://a/1", PublishedAt: time.Date(2026, 10, 5, 14, 5, 0, 0, time.UTC)},
+				{Title: "Merkez Bankası faizi değiştirmedi", URL: "https://b/1"},
+				{Title: "Merkez Bankası faizi sabit bıraktı", URL: "https://c/1", PublishedAt: time.Date(2026, 10, 5, 14, 0, 0, 0, time.UTC)},
+			},
+			threshold: DefaultThreshold,
+			want: [][]string{{
+				"Merkez Bankası faizi sabit bıraktı",
+				"Merkez Bankası faizi sabit tuttu",
+				"Merkez Bankası faizi değiştirmedi",
+			}},
+		},
+		{
+			name:      "no items",
+			threshold: DefaultThreshold,
+		},
+	} {
+		var got [][]string
+		for _, c := range Group(tt.items, tt.threshold) {
+			var titles []string
+			for _, item := range c.Items {
+				titles = append(titles, item.Title)
+			}
+			got = append(got, titles)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Group = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// items returns news items with the given titles, each with its own URL
+func items(titles ...string) []sources.NewsItem {
+	var items []sources.NewsItem
+	for _, title := range titles {
+		items = append(items, sources.NewsItem{Title: title, URL: "https://example.com/" + strings.ToLower(strings.Fields(title)[0]) + "/" + title})
+	}
+	return items
+}
+
+// sameSet reports whether a and b hold the same strings, in any order
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]bool)
+	for _, s := range a {
+		seen[s] = true
+	}
+	for _, s := range b {
+		if !seen[s] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,296 @@
+// Package config loads the user configuration file, in which additional
+// news sources can be declared without recompiling HaberlerPlus.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/filter"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources/impl"
+)
+
+// Config is the content of the configuration file
+type Config struct {
+	Sources []SourceConfig `yaml:"sources"`
+	// Disabled lists the ids of sources that should not be used
+	Disabled []string `yaml:"disabled"`
+	// Filters selects the news shown, like the -include and -exclude flags
+	Filters FilterConfig `yaml:"filters"`
+
+	path string
+}
+
+// FilterConfig lists filter patterns, see package filter for their syntax
+type FilterConfig struct {
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+
+	line int
+}
+
+// Source types
+const (
+	TypeRSS  = "rss"
+	TypeHTML = "html"
+)
+
+// SourceConfig declares a news source. Feed sources (type "rss", the
+// default) read each category from a feed URL; HTML sources (type "html")
+// scrape category pages with CSS selectors.
+type SourceConfig struct {
+	// ID is the registry id of the source. It defaults to the name reduced
+	// to lower case ASCII letters and digits; using the id of a built-in
+	// source replaces that source.
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	// Language and Homepage are informational, see sources.Metadata
+	Language   string           `yaml:"language"`
+	Homepage   string           `yaml:"homepage"`
+	Categories []CategoryConfig `yaml:"categories"`
+	// MaxItems caps the number of items per category of a feed source,
+	// zero means the default
+	MaxItems int `yaml:"max_items"`
+	// FallbackURL is used for feed items without an absolute link, see impl.RSSConfig
+	FallbackURL string `yaml:"fallback_url"`
+
+	// URL is the category page template of an HTML source, see impl.SelectorConfig
+	URL       string       `yaml:"url"`
+	BaseURL   string       `yaml:"base_url"`
+	Items     []RuleConfig `yaml:"items"`
+	Fallbacks []RuleConfig `yaml:"fallbacks"`
+	MinItems  int          `yaml:"min_items"`
+	Dedupe    bool         `yaml:"dedupe"`
+
+	line int
+}
+
+// CategoryConfig declares a category of a source
+type CategoryConfig struct {
+	Name string `yaml:"name"`
+	// URL is the feed of the category of a feed source
+	URL string `yaml:"url"`
+	// Path is substituted for {category} in the source's URL templates
+	Path string `yaml:"path"`
+	// Topic is the canonical topic of the category, e.g. "ekonomi". It is
+	// only needed when it cannot be derived from the name.
+	Topic string `yaml:"topic"`
+
+	line int
+}
+
+// RuleConfig declares how items are scraped from an HTML page,
+// see impl.SelectorRule
+type RuleConfig struct {
+	Item                string   `yaml:"item"`
+	Title               Selector `yaml:"title"`
+	Link                Selector `yaml:"link"`
+	Date                Selector `yaml:"date"`
+	Summary             Selector `yaml:"summary"`
+	Image               Selector `yaml:"image"`
+	TitleCut            string   `yaml:"title_cut"`
+	MinTitleLength      int      `yaml:"min_title_length"`
+	MaxTitleLength      int      `yaml:"max_title_length"`
+	URLContainsCategory bool     `yaml:"url_contains_category"`
+
+	line int
+}
+
+// Selector is a chain of CSS selectors tried in order. In the file it is
+// written either as a single selector or as a list of them.
+type Selector []string
+
+// Error is a validation error pointing to the offending line of the file
+type Error struct {
+	Path string
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Msg)
+}
+
+// DefaultPath returns the default location of the configuration file,
+// e.g. ~/.config/haberlerplus/config.yaml on Linux.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "haberlerplus", "config.yaml"), nil
+}
+
+// Load reads and validates the configuration file at path. When path is
+// empty the default location is used, and a missing file there yields an
+// empty configuration rather than an error.
+func Load(path string) (*Config, error) {
+	optional := path == ""
+	if optional {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return &Config{}, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, os.ErrNotExist) {
+			return &Config{path: path}, nil
+		}
+		return nil, err
+	}
+
+	return Parse(path, data)
+}
+
+// Parse parses and validates configuration data; path is only used in errors
+func Parse(path string, data []byte) (*Config, error) {
+	cfg := &Config{path: path}
+
+	var root yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&root); err != nil {
+		if err == io.EOF {
+			return cfg, nil
+		}
+		return nil, &Error{Path: path, Msg: err.Error()}
+	}
+
+	if err := checkKeys(path, root.Content[0], "sources", "disabled", "filters"); err != nil {
+		return nil, err
+	}
+	if err := root.Decode(cfg); err != nil {
+		var cfgErr *Error
+		if errors.As(err, &cfgErr) {
+			cfgErr.Path = path
+			return nil, cfgErr
+		}
+		return nil, &Error{Path: path, Msg: err.Error()}
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Filter returns the filter declared in the configuration combined with
+// additional include and exclude patterns, e.g. from the command line
+func (c *Config) Filter(include, exclude []string) (*filter.Filter, error) {
+	include = append(append([]string(nil), c.Filters.Include...), include...)
+	exclude = append(append([]string(nil), c.Filters.Exclude...), exclude...)
+	return filter.New(include, exclude)
+}
+
+// Path returns the file the configuration was loaded from
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Register adds the sources declared in the configuration to the source
+// registry, replacing built-in sources with the same id, and disables the
+// sources listed under disabled.
+func (c *Config) Register() error {
+	for _, s := range c.Sources {
+		s := s
+		meta := sources.Metadata{Name: s.Name, Language: s.Language, Type: sources.TypeRSS, Homepage: s.Homepage, Topics: s.topics()}
+		if meta.Language == "" {
+			meta.Language = "tr"
+		}
+		factory := func() sources.NewsSource { return impl.NewRSSSourceFromConfig(s.rssConfig()) }
+		if s.Type == TypeHTML {
+			meta.Type = sources.TypeHTML
+			factory = func() sources.NewsSource { return impl.NewSelectorSource(s.selectorConfig()) }
+		}
+		sources.Replace(s.id(), factory, meta)
+	}
+
+	for _, id := range c.Disabled {
+		if err := sources.Disable(id); err != nil {
+			return &Error{Path: c.path, Msg: fmt.Sprintf("disabled: %v", err)}
+		}
+	}
+	return nil
+}
+
+// topics returns the explicit topics of the categories of the source
+func (s SourceConfig) topics() map[string]sources.Topic {
+	topics := make(map[string]sources.Topic)
+	for _, cat := range s.Categories {
+		if topic, ok := sources.ParseTopic(cat.Topic); ok {
+			topics[cat.Name] = topic
+		}
+	}
+	return topics
+}
+
+// id returns the registry id of the source
+func (s SourceConfig) id() string {
+	if s.ID != "" {
+		return s.ID
+	}
+	return sources.MakeID(s.Name)
+}
+
+func (s SourceConfig) rssConfig() impl.RSSConfig {
+	rc := impl.RSSConfig{
+		Name:          s.Name,
+		FeedURLs:      make(map[string]string, len(s.Categories)),
+		MaxItems:      s.MaxItems,
+		FallbackURL:   s.FallbackURL,
+		CategoryPaths: make(map[string]string, len(s.Categories)),
+	}
+	for _, c := range s.Categories {
+		rc.Categories = append(rc.Categories, c.Name)
+		rc.FeedURLs[c.Name] = c.URL
+		rc.CategoryPaths[c.Name] = c.Path
+	}
+	return rc
+}
+
+func (s SourceConfig) selectorConfig() impl.SelectorConfig {
+	sc := impl.SelectorConfig{
+		Name:          s.Name,
+		URLTemplate:   s.URL,
+		CategoryPaths: make(map[string]string, len(s.Categories)),
+		BaseURL:       s.BaseURL,
+		MinItems:      s.MinItems,
+		Dedupe:        s.Dedupe,
+	}
+	for _, c := range s.Categories {
+		sc.Categories = append(sc.Categories, c.Name)
+		sc.CategoryPaths[c.Name] = c.Path
+	}
+	for _, r := range s.Items {
+		sc.Rules = append(sc.Rules, r.rule())
+	}
+	for _, r := range s.Fallbacks {
+		sc.Fallbacks = append(sc.Fallbacks, r.rule())
+	}
+	return sc
+}
+
+func (r RuleConfig) rule() impl.SelectorRule {
+	return impl.SelectorRule{
+		Item:                r.Item,
+		Title:               r.Title,
+		Link:                r.Link,
+		Date:                r.Date,
+		Summary:             r.Summary,
+		Image:               r.Image,
+		TitleCut:            r.TitleCut,
+		MinTitleLength:      r.MinTitleLength,
+		MaxTitleLength:      r.MaxTitleLength,
+		URLContainsCategory: r.URLContainsCategory,
+	}
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"gopkg.in/yaml.v3"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/filter"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+// UnmarshalYAML decodes a source, rejecting unknown keys and remembering its line
+func (s *SourceConfig) UnmarshalYAML(node *yaml.Node) error {
+	if err := checkKeys("", node, "id", "name", "type", "language", "homepage", "categories", "max_items", "fallback_url",
+		"url", "base_url", "items", "fallbacks", "min_items", "dedupe"); err != nil {
+		return err
+	}
+	type plain SourceConfig
+	if err := node.Decode((*plain)(s)); err != nil {
+		return err
+	}
+	s.line = node.Line
+	return nil
+}
+
+// UnmarshalYAML decodes a category, rejecting unknown keys and remembering its line
+func (c *CategoryConfig) UnmarshalYAML(node *yaml.Node) error {
+	if err := checkKeys("", node, "name", "url", "path", "topic"); err != nil {
+		return err
+	}
+	type plain CategoryConfig
+	if err := node.Decode((*plain)(c)); err != nil {
+		return err
+	}
+	c.line = node.Line
+	return nil
+}
+
+// UnmarshalYAML decodes a scraping rule, rejecting unknown keys and remembering its line
+func (r *RuleConfig) UnmarshalYAML(node *yaml.Node) error {
+	if err := checkKeys("", node, "item", "title", "link", "date", "summary", "image",
+		"title_cut", "min_title_length", "max_title_length", "url_contains_category"); err != nil {
+		return err
+	}
+	type plain RuleConfig
+	if err := node.Decode((*plain)(r)); err != nil {
+		return err
+	}
+	r.line = node.Line
+	return nil
+}
+
+// UnmarshalYAML decodes the filters, rejecting unknown keys and remembering their line
+func (f *FilterConfig) UnmarshalYAML(node *yaml.Node) error {
+	if err := checkKeys("", node, "include", "exclude"); err != nil {
+		return err
+	}
+	type plain FilterConfig
+	if err := node.Decode((*plain)(f)); err != nil {
+		return err
+	}
+	f.line = node.Line
+	return nil
+}
+
+// UnmarshalYAML decodes a selector chain written as a string or a list
+func (s *Selector) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var single string
+		if err := node.Decode(&single); err != nil {
+			return err
+		}
+		*s = Selector{single}
+		return nil
+	}
+	var chain []string
+	if err := node.Decode(&chain); err != nil {
+		return err
+	}
+	*s = chain
+	return nil
+}
+
+// topicNames lists the canonical topics for error messages
+func topicNames() string {
+	names := make([]string, len(sources.Topics))
+	for i, topic := range sources.Topics {
+		names[i] = string(topic)
+	}
+	return strings.Join(names, ", ")
+}
+
+// checkKeys reports keys of a mapping node that are not in allowed
+func checkKeys(path string, node *yaml.Node, allowed ...string) error {
+	if node.Kind != yaml.MappingNode {
+		if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+			return nil
+		}
+		return &Error{Path: path, Line: node.Line, Msg: "expected a mapping"}
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+		known := false
+		for _, a := range allowed {
+			if key.Value == a {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return &Error{Path: path, Line: key.Line, Msg: fmt.Sprintf("unknown key %q (expected one of %s)", key.Value, strings.Join(allowed, ", "))}
+		}
+	}
+	return nil
+}
+
+// validate checks the decoded configuration for semantic errors
+func (c *Config) validate() error {
+	ids := make(map[string]int)
+	for _, s := range c.Sources {
+		fail := func(line int, format string, args ...interface{}) error {
+			msg := fmt.Sprintf(format, args...)
+			if s.Name != "" {
+				msg = fmt.Sprintf("source %q: %s", s.Name, msg)
+			}
+			return &Error{Path: c.path, Line: line, Msg: msg}
+		}
+
+		if strings.TrimSpace(s.Name) == "" {
+			return fail(s.line, "source has no name")
+		}
+		if s.id() == "" {
+			return fail(s.line, "cannot derive an id from the name, please set one")
+		}
+		if first, ok := ids[s.id()]; ok {
+			return fail(s.line, "duplicate source id %q, first declared on line %d", s.id(), first)
+		}
+		ids[s.id()] = s.line
+
+		switch s.Type {
+		case "", TypeRSS, TypeHTML:
+		default:
+			return fail(s.line, "unknown type %q (expected %s or %s)", s.Type, TypeRSS, TypeHTML)
+		}
+		if len(s.Categories) == 0 {
+			return fail(s.line, "source has no categories")
+		}
+		if s.MaxItems < 0 || s.MinItems < 0 {
+			return fail(s.line, "max_items and min_items must not be negative")
+		}
+		if s.FallbackURL != "" && !isAbsoluteURL(s.FallbackURL) {
+			return fail(s.line, "fallback_url %q is not an absolute http(s) URL", s.FallbackURL)
+		}
+
+		categories := make(map[string]int)
+		for _, cat := range s.Categories {
+			if strings.TrimSpace(cat.Name) == "" {
+				return fail(cat.line, "category has no name")
+			}
+			if first, ok := categories[cat.Name]; ok {
+				return fail(cat.line, "duplicate category %q, first declared on line %d", cat.Name, first)
+			}
+			categories[cat.Name] = cat.line
+			if cat.Topic != "" {
+				if _, ok := sources.ParseTopic(cat.Topic); !ok {
+					return fail(cat.line, "category %q: unknown topic %q (expected one of %s)", cat.Name, cat.Topic, topicNames())
+				}
+			}
+
+			if s.Type == TypeHTML {
+				continue
+			}
+			if cat.URL == "" {
+				return fail(cat.line, "category %q has no url", cat.Name)
+			}
+			if !isAbsoluteURL(cat.URL) {
+				return fail(cat.line, "category %q: url %q is not an absolute http(s) URL", cat.Name, cat.URL)
+			}
+		}
+
+		if s.Type != TypeHTML {
+			if s.URL != "" || len(s.Items) > 0 || len(s.Fallbacks) > 0 {
+				return fail(s.line, "url, items and fallbacks are only allowed for sources of type %s", TypeHTML)
+			}
+			continue
+		}
+
+		if !isAbsoluteURL(strings.ReplaceAll(s.URL, "{category}", "x")) {
+			return fail(s.line, "url %q is not an absolute http(s) URL", s.URL)
+		}
+		if s.BaseURL != "" && !isAbsoluteURL(s.BaseURL) {
+			return fail(s.line, "base_url %q is not an absolute http(s) URL", s.BaseURL)
+		}
+		if len(s.Items) == 0 {
+			return fail(s.line, "source has no items rules")
+		}
+		for _, r := range append(append([]RuleConfig{}, s.Items...), s.Fallbacks...) {
+			if err := r.validate(); err != nil {
+				return fail(r.line, "%v", err)
+			}
+		}
+	}
+
+	if _, err := filter.New(c.Filters.Include, c.Filters.Exclude); err != nil {
+		return &Error{Path: c.path, Line: c.Filters.line, Msg: fmt.Sprintf("filters: %v", err)}
+	}
+	return nil
+}
+
+// validate checks that all selectors of a rule are valid CSS
+func (r RuleConfig) validate() error {
+	if strings.TrimSpace(r.Item) == "" {
+		return fmt.Errorf("rule has no item selector")
+	}
+	if r.MinTitleLength < 0 || r.MaxTitleLength < 0 {
+		return fmt.Errorf("title lengths must not be negative")
+	}
+
+	selectors := []string{r.Item}
+	for _, chain := range []Selector{r.Title, r.Link, r.Date, r.Summary, r.Image} {
+		selectors = append(selectors, chain...)
+	}
+	for _, sel := range selectors {
+		if _, err := cascadia.ParseGroup(strings.ReplaceAll(sel, "{category}", "x")); err != nil {
+			return fmt.Errorf("invalid selector %q: %v", sel, err)
+		}
+	}
+	return nil
+}
+
+func isAbsoluteURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
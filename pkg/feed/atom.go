@@ -0,0 +1,141 @@
+package feed
+
+import (
+	"strconv"
+	"strings"
+)
+
+type atomDoc struct {
+	ID       string      `xml:"id"`
+	Title    atomText    `xml:"title"`
+	Subtitle atomText    `xml:"subtitle"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     atomText   `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	Summary   atomText   `xml:"summary"`
+	Content   atomText   `xml:"content"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Authors   []struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Categories []struct {
+		Term  string `xml:"term,attr"`
+		Label string `xml:"label,attr"`
+	} `xml:"category"`
+	Source struct {
+		Title atomText   `xml:"title"`
+		Links []atomLink `xml:"link"`
+	} `xml:"source"`
+	MediaContents   []mediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []mediaContent `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+// atomText is an Atom text construct. XHTML content is kept as markup,
+// text and HTML content as their unescaped character data.
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+func (t atomText) String() string {
+	if t.Type == "xhtml" {
+		return strings.TrimSpace(t.Inner)
+	}
+	return strings.TrimSpace(t.Text)
+}
+
+func parseAtom(data []byte) (*Feed, error) {
+	var doc atomDoc
+	if err := newDecoder(data).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	f := &Feed{
+		Format:      Atom,
+		ID:          strings.TrimSpace(doc.ID),
+		Title:       doc.Title.String(),
+		Link:        preferredLink(convertAtomLinks(doc.Links)),
+		Description: doc.Subtitle.String(),
+		Items:       make([]Item, 0, len(doc.Entries)),
+	}
+	for _, e := range doc.Entries {
+		f.Items = append(f.Items, e.item())
+	}
+	return f, nil
+}
+
+func (e atomEntry) item() Item {
+	item := Item{
+		ID:      strings.TrimSpace(e.ID),
+		Title:   e.Title.String(),
+		Links:   convertAtomLinks(e.Links),
+		Summary: e.Summary.String(),
+		Content: e.Content.String(),
+	}
+	item.Link = preferredLink(item.Links)
+	item.Source = Source{Title: e.Source.Title.String(), URL: preferredLink(convertAtomLinks(e.Source.Links))}
+	item.Published, _ = ParseDate(e.Published)
+	item.Updated, _ = ParseDate(e.Updated)
+	if item.Published.IsZero() {
+		item.Published = item.Updated
+	}
+
+	for _, a := range e.Authors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			item.Authors = append(item.Authors, name)
+		}
+	}
+	for _, c := range e.Categories {
+		label := strings.TrimSpace(c.Label)
+		if label == "" {
+			label = strings.TrimSpace(c.Term)
+		}
+		if label != "" {
+			item.Categories = append(item.Categories, label)
+		}
+	}
+
+	for _, l := range e.Links {
+		if l.Rel == "enclosure" {
+			length, _ := strconv.ParseInt(l.Length, 10, 64)
+			item.addEnclosure(l.Href, l.Type, length)
+		}
+	}
+	for _, m := range append(append([]mediaContent{}, e.MediaContents...), e.MediaThumbnails...) {
+		size, _ := strconv.ParseInt(m.FileSize, 10, 64)
+		item.addEnclosure(m.URL, m.Type, size)
+	}
+
+	return item
+}
+
+func convertAtomLinks(links []atomLink) []Link {
+	out := make([]Link, 0, len(links))
+	for _, l := range links {
+		href := strings.TrimSpace(l.Href)
+		if href == "" {
+			continue
+		}
+		// A link without a relation is an alternate link per RFC 4287
+		rel := l.Rel
+		if rel == "" {
+			rel = "alternate"
+		}
+		out = append(out, Link{Href: href, Rel: rel, Type: l.Type})
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package feed
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// charsetReader converts the single byte charsets used by Turkish
+// publishers to UTF-8. UTF-8 documents never reach it.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	var table *[256]rune
+	switch strings.ToLower(charset) {
+	case "iso-8859-9", "iso8859-9", "latin5":
+		table = &iso88599
+	case "windows-1254", "cp1254":
+		table = &windows1254
+	case "iso-8859-1", "latin1":
+		table = &iso88591
+	default:
+		return nil, fmt.Errorf("feed: unsupported charset %q", charset)
+	}
+
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data)+len(data)/4)
+	for _, b := range data {
+		out = utf8.AppendRune(out, table[b])
+	}
+	return strings.NewReader(string(out)), nil
+}
+
+var iso88591, iso88599, windows1254 [256]rune
+
+func init() {
+	for i := range iso88591 {
+		iso88591[i] = rune(i)
+	}
+
+	// ISO-8859-9 differs from Latin-1 only in the six Turkish letters
+	iso88599 = iso88591
+	iso88599[0xD0] = 'Ğ'
+	iso88599[0xDD] = 'İ'
+	iso88599[0xDE] = 'Ş'
+	iso88599[0xF0] = 'ğ'
+	iso88599[0xFD] = 'ı'
+	iso88599[0xFE] = 'ş'
+
+	// Windows-1254 is ISO-8859-9 with printable characters in 0x80-0x9F
+	windows1254 = iso88599
+	for i, r := range []rune{
+		'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 0x8E, 0x8F,
+		0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 0x9E, 'Ÿ',
+	} {
+		windows1254[0x80+i] = r
+	}
+}
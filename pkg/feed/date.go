@@ -0,0 +1,130 @@
+package feed
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Istanbul is the time zone assumed for dates that carry no zone.
+// Turkey has been on UTC+3 all year since 2016; a fixed zone avoids
+// depending on the tz database, which minimal containers lack.
+var Istanbul = time.FixedZone("TRT", 3*60*60)
+
+// dateLayouts are the layouts tried by ParseDate, after weekdays have been
+// stripped and Turkish month names translated.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04 MST",
+	"2 Jan 2006 15:04:05",
+	"2 Jan 2006 15:04",
+	"2 January 2006 15:04:05 -0700",
+	"2 January 2006 15:04:05",
+	"2 January 2006 15:04",
+	"2 January 2006",
+	"2 Jan 2006",
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05-07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02/01/2006",
+	"Jan 2, 2006 15:04:05 -0700",
+	"Jan 2, 2006 3:04:05 PM",
+	"Jan 2, 2006",
+}
+
+// turkishMonths maps Turkish month names and abbreviations to English
+var turkishMonths = strings.NewReplacer(
+	"Ocak", "January", "Şubat", "February", "Mart", "March", "Nisan", "April",
+	"Mayıs", "May", "Haziran", "June", "Temmuz", "July", "Ağustos", "August",
+	"Eylül", "September", "Ekim", "October", "Kasım", "November", "Aralık", "December",
+	"Oca", "Jan", "Şub", "Feb", "Mar", "Mar", "Nis", "Apr", "May", "May", "Haz", "Jun",
+	"Tem", "Jul", "Ağu", "Aug", "Eyl", "Sep", "Eki", "Oct", "Kas", "Nov", "Ara", "Dec",
+)
+
+// zoneAbbreviations maps zone names Go cannot resolve on its own to offsets
+var zoneAbbreviations = map[string]string{
+	"TRT":  "+0300",
+	"TSİ":  "+0300",
+	"TSI":  "+0300",
+	"EEST": "+0300",
+	"EET":  "+0200",
+	"CEST": "+0200",
+	"CET":  "+0100",
+	"BST":  "+0100",
+	"UT":   "+0000",
+	"Z":    "+0000",
+}
+
+var (
+	// weekdayPrefix matches a leading English or Turkish weekday name
+	weekdayPrefix = regexp.MustCompile(`^[\p{L}]+\.?,?\s+`)
+	// gmtOffset matches offsets written as GMT+3, GMT+03:00 or UTC+0300
+	gmtOffset = regexp.MustCompile(`\s*(?:GMT|UTC)\s*([+-])(\d{1,2}):?(\d{2})?$`)
+	// zoneSuffix matches a trailing alphabetic zone name
+	zoneSuffix = regexp.MustCompile(`\s([\p{Lu}]{1,4})$`)
+	spaces     = regexp.MustCompile(`\s+`)
+)
+
+// ParseDate parses the many date formats found in feeds: RFC 822 and RFC 3339
+// variants, dates with Turkish weekday and month names, GMT+3 style offsets
+// and plain local dates, which are taken to be in Istanbul time.
+func ParseDate(value string) (time.Time, error) {
+	s := strings.TrimSpace(spaces.ReplaceAllString(value, " "))
+	if s == "" {
+		return time.Time{}, fmt.Errorf("feed: empty date")
+	}
+
+	s = normalizeDate(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, Istanbul); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("feed: unrecognized date %q", value)
+}
+
+// normalizeDate rewrites a date into a shape the layouts above understand
+func normalizeDate(s string) string {
+	// Drop weekday names, which are redundant and often misspelled
+	if !startsWithDigit(s) {
+		if stripped := weekdayPrefix.ReplaceAllString(s, ""); startsWithDigit(stripped) {
+			s = stripped
+		}
+	}
+
+	s = turkishMonths.Replace(s)
+
+	if m := gmtOffset.FindStringSubmatch(s); m != nil {
+		minutes := m[3]
+		if minutes == "" {
+			minutes = "00"
+		}
+		s = gmtOffset.ReplaceAllString(s, fmt.Sprintf(" %s%02s%s", m[1], m[2], minutes))
+	}
+
+	if m := zoneSuffix.FindStringSubmatch(s); m != nil {
+		if offset, ok := zoneAbbreviations[m[1]]; ok {
+			s = strings.TrimSuffix(s, m[1]) + offset
+		}
+	}
+
+	return s
+}
+
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
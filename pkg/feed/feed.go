@@ -0,0 +1,159 @@
+// Package feed parses syndication feeds in the RSS 2.0, RSS 1.0 (RDF),
+// Atom and JSON Feed formats into a single format-independent model, and
+// writes that model as RSS 2.0 or Atom.
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Format identifies the syndication format a feed was written in
+type Format string
+
+// Supported feed formats
+const (
+	RSS2     Format = "rss2"
+	RSS1     Format = "rss1"
+	Atom     Format = "atom"
+	JSONFeed Format = "json"
+)
+
+// ErrUnknownFormat is returned when a document is not a recognized feed
+var ErrUnknownFormat = errors.New("feed: unknown feed format")
+
+// Feed is a parsed feed
+type Feed struct {
+	Format Format
+	// ID is the Atom id of the feed, if any
+	ID          string
+	Title       string
+	Link        string
+	Description string
+	Items       []Item
+}
+
+// Item is a single entry of a feed
+type Item struct {
+	// ID is the GUID, Atom id or JSON Feed id of the item
+	ID    string
+	Title string
+	// Link is the preferred URL of the item, see Links for all of them
+	Link  string
+	Links []Link
+	// Summary is the short description of the item, which may contain HTML
+	Summary string
+	// Content is the full content of the item, which may contain HTML
+	Content    string
+	Published  time.Time
+	Updated    time.Time
+	Authors    []string
+	Categories []string
+	Enclosures []Enclosure
+	// Source is the feed the item was taken from, for aggregated feeds
+	Source Source
+}
+
+// Source identifies the feed an item of an aggregated feed comes from
+type Source struct {
+	Title string
+	URL   string
+}
+
+// Link is a link of an item along with its relation, e.g. "alternate"
+type Link struct {
+	Href string
+	Rel  string
+	Type string
+}
+
+// Enclosure is a media object attached to an item
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length int64
+}
+
+// ImageURL returns the first image enclosure of the item, if any.
+// Enclosures without a type are assumed to be images, which is how most
+// media:content and media:thumbnail elements in the wild are written.
+func (i Item) ImageURL() string {
+	for _, e := range i.Enclosures {
+		if e.Type == "" || strings.HasPrefix(e.Type, "image/") {
+			return e.URL
+		}
+	}
+	return ""
+}
+
+// Parse detects the format of data and parses it as a feed
+func Parse(data []byte) (*Feed, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("%w: empty document", ErrUnknownFormat)
+	}
+
+	if trimmed[0] == '{' {
+		return parseJSONFeed(trimmed)
+	}
+
+	root, err := rootElement(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(root.Local) {
+	case "rss":
+		return parseRSS2(trimmed)
+	case "rdf":
+		return parseRSS1(trimmed)
+	case "feed":
+		return parseAtom(trimmed)
+	default:
+		return nil, fmt.Errorf("%w: unexpected root element <%s>", ErrUnknownFormat, root.Local)
+	}
+}
+
+// rootElement returns the name of the first element of an XML document
+func rootElement(data []byte) (xml.Name, error) {
+	dec := newDecoder(data)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return xml.Name{}, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name, nil
+		}
+	}
+}
+
+// newDecoder returns a lenient XML decoder that copes with the HTML entities
+// and non UTF-8 charsets some publishers put into their feeds.
+func newDecoder(data []byte) *xml.Decoder {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	dec.CharsetReader = charsetReader
+	return dec
+}
+
+// preferredLink picks the link readers should follow: the first alternate
+// link, or failing that the first link without a relation.
+func preferredLink(links []Link) string {
+	for _, l := range links {
+		if l.Rel == "alternate" && l.Href != "" {
+			return l.Href
+		}
+	}
+	for _, l := range links {
+		if l.Rel == "" && l.Href != "" {
+			return l.Href
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestParseGolden parses every feed in testdata and compares the result
+// with the JSON encoding checked in next to it, e.g. rss2.xml with
+// rss2.golden.json. Run "go test ./pkg/feed -update" to regenerate them.
+func TestParseGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range inputs {
+		if strings.HasSuffix(input, ".golden.json") {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := Parse(data)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			got, err := json.MarshalIndent(f, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			golden := filepath.Join("testdata", name+".golden.json")
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Parse(%s) differs from %s:\n%s", input, golden, got)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2026, 10, 5, 8, 0, 0, 0, Istanbul)
+	for _, value := range []string{
+		"Mon, 05 Oct 2026 08:00:00 +0300",
+		"Mon, 05 Oct 2026 05:00:00 GMT",
+		"Mon, 05 Oct 2026 05:00:00 UT",
+		"Mon, 5 Oct 2026 08:00 +0300",
+		"Mon, 05 Oct 2026 08:00:00 GMT+3",
+		"Mon, 05 Oct 2026 08:00:00 GMT+03:00",
+		"05 Oct 2026 08:00:00 TSİ",
+		"05 Oct 2026 08:00:00 TRT",
+		"Pazartesi, 05 Ekim 2026 08:00:00",
+		"Pzt, 05 Eki 2026 08:00:00 +0300",
+		"5 Ekim 2026 08:00",
+		"05.10.2026 08:00",
+		"05/10/2026 08:00:00",
+		"2026-10-05 08:00:00",
+		"2026-10-05T08:00:00",
+		"2026-10-05T08:00:00+03:00",
+		"2026-10-05T05:00:00Z",
+		"2026-10-05T08:00:00+0300",
+		"  Mon,  05 Oct 2026\n08:00:00 +0300 ",
+	} {
+		got, err := ParseDate(value)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %v", value, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, want %v", value, got, want)
+		}
+	}
+
+	for _, value := range []string{"", "dün akşam", "32.13.2026"} {
+		if got, err := ParseDate(value); err == nil {
+			t.Errorf("ParseDate(%q) = %v, want an error", value, got)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonFeedDoc struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	Description string         `json:"description"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            json.RawMessage  `json:"id"`
+	URL           string           `json:"url"`
+	ExternalURL   string           `json:"external_url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary"`
+	Image         string           `json:"image"`
+	BannerImage   string           `json:"banner_image"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Tags          []string         `json:"tags"`
+	Author        *jsonFeedAuthor  `json:"author"`
+	Authors       []jsonFeedAuthor `json:"authors"`
+	Attachments   []struct {
+		URL         string `json:"url"`
+		MimeType    string `json:"mime_type"`
+		SizeInBytes int64  `json:"size_in_bytes"`
+	} `json:"attachments"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+func parseJSONFeed(data []byte) (*Feed, error) {
+	var doc jsonFeedDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.Version, "https://jsonfeed.org/version/") {
+		return nil, fmt.Errorf("%w: JSON document is not a JSON Feed", ErrUnknownFormat)
+	}
+
+	f := &Feed{
+		Format:      JSONFeed,
+		Title:       strings.TrimSpace(doc.Title),
+		Link:        strings.TrimSpace(doc.HomePageURL),
+		Description: strings.TrimSpace(doc.Description),
+		Items:       make([]Item, 0, len(doc.Items)),
+	}
+	for _, e := range doc.Items {
+		f.Items = append(f.Items, e.item())
+	}
+	return f, nil
+}
+
+func (e jsonFeedItem) item() Item {
+	item := Item{
+		ID:         jsonFeedID(e.ID),
+		Title:      strings.TrimSpace(e.Title),
+		Link:       strings.TrimSpace(e.URL),
+		Summary:    strings.TrimSpace(e.Summary),
+		Content:    strings.TrimSpace(e.ContentHTML),
+		Categories: e.Tags,
+	}
+	if item.Content == "" {
+		item.Content = strings.TrimSpace(e.ContentText)
+	}
+	if item.Link != "" {
+		item.Links = append(item.Links, Link{Href: item.Link, Rel: "alternate"})
+	}
+	if external := strings.TrimSpace(e.ExternalURL); external != "" {
+		item.Links = append(item.Links, Link{Href: external, Rel: "related"})
+		if item.Link == "" {
+			item.Link = external
+		}
+	}
+
+	item.Published, _ = ParseDate(e.DatePublished)
+	item.Updated, _ = ParseDate(e.DateModified)
+	if item.Published.IsZero() {
+		item.Published = item.Updated
+	}
+
+	if e.Author != nil && e.Author.Name != "" {
+		item.Authors = append(item.Authors, e.Author.Name)
+	}
+	for _, a := range e.Authors {
+		if a.Name != "" {
+			item.Authors = append(item.Authors, a.Name)
+		}
+	}
+
+	item.addEnclosure(e.Image, "", 0)
+	item.addEnclosure(e.BannerImage, "", 0)
+	for _, a := range e.Attachments {
+		item.addEnclosure(a.URL, a.MimeType, a.SizeInBytes)
+	}
+
+	return item
+}
+
+// jsonFeedID returns a JSON Feed item id, which should be a string but is a
+// number in some feeds.
+func jsonFeedID(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s)
+	}
+	return strings.TrimSpace(string(raw))
+}
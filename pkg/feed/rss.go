@@ -0,0 +1,192 @@
+package feed
+
+import (
+	"strconv"
+	"strings"
+)
+
+type rss2Doc struct {
+	Channel struct {
+		Title       string     `xml:"title"`
+		Links       []string   `xml:"link"`
+		Description string     `xml:"description"`
+		Items       []rssEntry `xml:"item"`
+	} `xml:"channel"`
+}
+
+// rss1Doc is an RSS 1.0 document, where items are siblings of the channel
+type rss1Doc struct {
+	Channel struct {
+		Title       string   `xml:"title"`
+		Links       []string `xml:"link"`
+		Description string   `xml:"description"`
+	} `xml:"channel"`
+	Items []rssEntry `xml:"item"`
+}
+
+// rssEntry holds the elements of an RSS 2.0 or RSS 1.0 item
+type rssEntry struct {
+	About       string   `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	Title       string   `xml:"title"`
+	Links       []string `xml:"link"`
+	Description string   `xml:"description"`
+	Encoded     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PubDate     string   `xml:"pubDate"`
+	DCDate      string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Author      string   `xml:"author"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
+	GUID        struct {
+		Value       string `xml:",chardata"`
+		IsPermaLink string `xml:"isPermaLink,attr"`
+	} `xml:"guid"`
+	Source struct {
+		URL   string `xml:"url,attr"`
+		Title string `xml:",chardata"`
+	} `xml:"source"`
+	Enclosures []struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Length string `xml:"length,attr"`
+	} `xml:"enclosure"`
+	MediaContents   []mediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []mediaContent `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaGroups     []struct {
+		Contents   []mediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+		Thumbnails []mediaContent `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	} `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+type mediaContent struct {
+	URL      string `xml:"url,attr"`
+	Type     string `xml:"type,attr"`
+	Medium   string `xml:"medium,attr"`
+	FileSize string `xml:"fileSize,attr"`
+}
+
+func parseRSS2(data []byte) (*Feed, error) {
+	var doc rss2Doc
+	if err := newDecoder(data).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	f := &Feed{
+		Format:      RSS2,
+		Title:       strings.TrimSpace(doc.Channel.Title),
+		Link:        firstNonEmpty(doc.Channel.Links),
+		Description: strings.TrimSpace(doc.Channel.Description),
+		Items:       make([]Item, 0, len(doc.Channel.Items)),
+	}
+	for _, e := range doc.Channel.Items {
+		f.Items = append(f.Items, e.item())
+	}
+	return f, nil
+}
+
+func parseRSS1(data []byte) (*Feed, error) {
+	var doc rss1Doc
+	if err := newDecoder(data).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	f := &Feed{
+		Format:      RSS1,
+		Title:       strings.TrimSpace(doc.Channel.Title),
+		Link:        firstNonEmpty(doc.Channel.Links),
+		Description: strings.TrimSpace(doc.Channel.Description),
+		Items:       make([]Item, 0, len(doc.Items)),
+	}
+	for _, e := range doc.Items {
+		f.Items = append(f.Items, e.item())
+	}
+	return f, nil
+}
+
+func (e rssEntry) item() Item {
+	item := Item{
+		ID:      strings.TrimSpace(e.GUID.Value),
+		Title:   strings.TrimSpace(e.Title),
+		Link:    firstNonEmpty(e.Links),
+		Summary: strings.TrimSpace(e.Description),
+		Content: strings.TrimSpace(e.Encoded),
+		Source:  Source{Title: strings.TrimSpace(e.Source.Title), URL: strings.TrimSpace(e.Source.URL)},
+	}
+	if item.ID == "" {
+		item.ID = strings.TrimSpace(e.About)
+	}
+
+	// A GUID is a permalink unless stated otherwise
+	if item.Link == "" && e.GUID.IsPermaLink != "false" && isAbsoluteURL(item.ID) {
+		item.Link = item.ID
+	}
+	if item.Link != "" {
+		item.Links = []Link{{Href: item.Link, Rel: "alternate"}}
+	}
+
+	date := e.PubDate
+	if strings.TrimSpace(date) == "" {
+		date = e.DCDate
+	}
+	item.Published, _ = ParseDate(date)
+
+	for _, author := range []string{e.Author, e.Creator} {
+		if author = strings.TrimSpace(author); author != "" {
+			item.Authors = append(item.Authors, author)
+		}
+	}
+	for _, c := range e.Categories {
+		if c = strings.TrimSpace(c); c != "" {
+			item.Categories = append(item.Categories, c)
+		}
+	}
+
+	for _, enc := range e.Enclosures {
+		length, _ := strconv.ParseInt(enc.Length, 10, 64)
+		item.addEnclosure(enc.URL, enc.Type, length)
+	}
+	media := append(append([]mediaContent{}, e.MediaContents...), e.MediaThumbnails...)
+	for _, g := range e.MediaGroups {
+		media = append(media, g.Contents...)
+		media = append(media, g.Thumbnails...)
+	}
+	for _, m := range media {
+		typ := m.Type
+		if typ == "" && m.Medium != "" && m.Medium != "image" {
+			typ = m.Medium
+		}
+		size, _ := strconv.ParseInt(m.FileSize, 10, 64)
+		item.addEnclosure(m.URL, typ, size)
+	}
+
+	return item
+}
+
+// addEnclosure appends an enclosure unless its URL is empty or already known
+func (i *Item) addEnclosure(url, typ string, length int64) {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return
+	}
+	for _, e := range i.Enclosures {
+		if e.URL == url {
+			return
+		}
+	}
+	i.Enclosures = append(i.Enclosures, Enclosure{URL: url, Type: typ, Length: length})
+}
+
+// firstNonEmpty returns the first non-blank value. RSS elements are matched
+// by local name, so an atom:link next to the RSS link shows up as an empty
+// value in the same slice.
+func firstNonEmpty(values []string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func isAbsoluteURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
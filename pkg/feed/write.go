@@ -0,0 +1,302 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Generator names the program writing feeds in their generator element
+var Generator = "HaberlerPlus"
+
+// TagPrefix starts the tag: URIs (RFC 4151) minted as Atom ids for feeds
+// and entries that have no IRI of their own
+var TagPrefix = "tag:github.com,2024:furkandogmus/HaberlerPlus/"
+
+// Namespaces of the written feeds
+const (
+	atomNS = "http://www.w3.org/2005/Atom"
+	dcNS   = "http://purl.org/dc/elements/1.1/"
+)
+
+// Write serializes f to w in its format, which must be RSS2 or Atom.
+// Items with a Source are attributed to it with the source element of the
+// format. Atom feeds are identified by their ID, or else their Link.
+func Write(w io.Writer, f *Feed) error {
+	var doc any
+	switch f.Format {
+	case RSS2:
+		doc = rssOutput(f)
+	case Atom:
+		doc = atomOutput(f)
+	default:
+		return fmt.Errorf("feed: cannot write %q feeds", f.Format)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// updated returns the time the feed last changed: that of its newest item,
+// or now if no item has a time
+func (f *Feed) updated() time.Time {
+	var latest time.Time
+	for _, item := range f.Items {
+		if t := item.updated(); t.After(latest) {
+			latest = t
+		}
+	}
+	if latest.IsZero() {
+		return time.Now()
+	}
+	return latest
+}
+
+// updated returns the time the item last changed, zero if unknown
+func (i Item) updated() time.Time {
+	if !i.Updated.IsZero() {
+		return i.Updated
+	}
+	return i.Published
+}
+
+type rssOut struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	DCNS    string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Generator     string    `xml:"generator"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link,omitempty"`
+	Description string   `xml:"description,omitempty"`
+	Creators    []string `xml:"dc:creator"`
+	Categories  []string `xml:"category"`
+	GUID        *struct {
+		IsPermaLink bool   `xml:"isPermaLink,attr"`
+		Value       string `xml:",chardata"`
+	} `xml:"guid"`
+	PubDate   string `xml:"pubDate,omitempty"`
+	Enclosure *struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Length int64  `xml:"length,attr"`
+	} `xml:"enclosure"`
+	Source *struct {
+		URL   string `xml:"url,attr"`
+		Title string `xml:",chardata"`
+	} `xml:"source"`
+}
+
+func rssOutput(f *Feed) *rssOut {
+	doc := &rssOut{
+		Version: "2.0",
+		DCNS:    dcNS,
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			LastBuildDate: f.updated().Format(time.RFC1123Z),
+			Generator:     Generator,
+		},
+	}
+	if doc.Channel.Description == "" {
+		doc.Channel.Description = f.Title
+	}
+
+	for _, item := range f.Items {
+		out := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Summary,
+			Creators:    item.Authors,
+			Categories:  item.Categories,
+		}
+		if item.ID != "" {
+			out.GUID = &struct {
+				IsPermaLink bool   `xml:"isPermaLink,attr"`
+				Value       string `xml:",chardata"`
+			}{IsPermaLink: item.ID == item.Link, Value: item.ID}
+		}
+		if !item.Published.IsZero() {
+			out.PubDate = item.Published.Format(time.RFC1123Z)
+		}
+		if len(item.Enclosures) > 0 {
+			e := item.Enclosures[0]
+			out.Enclosure = &struct {
+				URL    string `xml:"url,attr"`
+				Type   string `xml:"type,attr"`
+				Length int64  `xml:"length,attr"`
+			}{URL: e.URL, Type: enclosureType(e), Length: e.Length}
+		}
+		// RSS requires the URL of the source; the title alone is dropped
+		if item.Source.URL != "" {
+			out.Source = &struct {
+				URL   string `xml:"url,attr"`
+				Title string `xml:",chardata"`
+			}{URL: item.Source.URL, Title: item.Source.Title}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, out)
+	}
+	return doc
+}
+
+// enclosureType returns the media type of an enclosure, assuming images
+// like Item.ImageURL does
+func enclosureType(e Enclosure) string {
+	if e.Type == "" {
+		return "image/jpeg"
+	}
+	return e.Type
+}
+
+type atomOut struct {
+	XMLName   xml.Name      `xml:"feed"`
+	NS        string        `xml:"xmlns,attr"`
+	ID        string        `xml:"id"`
+	Title     string        `xml:"title"`
+	Subtitle  string        `xml:"subtitle,omitempty"`
+	Links     []atomOutLink `xml:"link"`
+	Updated   string        `xml:"updated"`
+	Author    *atomPerson   `xml:"author"`
+	Generator string        `xml:"generator"`
+	Entries   []atomOutEntry
+}
+
+type atomOutLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomOutEntry struct {
+	XMLName    xml.Name       `xml:"entry"`
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomOutLink  `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Authors    []atomPerson   `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    string         `xml:"summary,omitempty"`
+	Source     *atomOutSource `xml:"source"`
+}
+
+// atomID returns the Atom id of the item. Atom ids must be IRIs, which
+// plain GUIDs like "12345" are not; these are replaced by the link of the
+// item, or by a tag: URI made of the source and the GUID when there is no
+// link.
+func (item Item) atomID() string {
+	if strings.Contains(item.ID, ":") {
+		return item.ID
+	}
+	if item.Link != "" {
+		return item.Link
+	}
+	key := item.ID
+	if key == "" {
+		key = item.Title
+	}
+	return TagPrefix + "entry/" + url.PathEscape(item.Source.Title) + "/" + url.PathEscape(key)
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomOutSource struct {
+	Title string        `xml:"title"`
+	Links []atomOutLink `xml:"link"`
+}
+
+func atomOutput(f *Feed) *atomOut {
+	updated := f.updated()
+	doc := &atomOut{
+		NS:        atomNS,
+		ID:        f.ID,
+		Title:     f.Title,
+		Subtitle:  f.Description,
+		Updated:   updated.Format(time.RFC3339),
+		Author:    &atomPerson{Name: f.Title},
+		Generator: Generator,
+	}
+	if f.Link != "" {
+		doc.Links = []atomOutLink{{Href: f.Link, Rel: "alternate"}}
+	}
+	if doc.ID == "" {
+		doc.ID = f.Link
+	}
+	if doc.ID == "" {
+		doc.ID = TagPrefix + "feed/" + url.PathEscape(f.Title)
+	}
+
+	for _, item := range f.Items {
+		out := atomOutEntry{
+			ID:      item.atomID(),
+			Title:   item.Title,
+			Summary: item.Summary,
+			Updated: updated.Format(time.RFC3339),
+		}
+		if item.Link != "" {
+			out.Links = append(out.Links, atomOutLink{Href: item.Link, Rel: "alternate"})
+		}
+		for _, e := range item.Enclosures {
+			length := ""
+			if e.Length > 0 {
+				length = strconv.FormatInt(e.Length, 10)
+			}
+			out.Links = append(out.Links, atomOutLink{Href: e.URL, Rel: "enclosure", Type: enclosureType(e), Length: length})
+		}
+		if !item.Published.IsZero() {
+			out.Published = item.Published.Format(time.RFC3339)
+		}
+		if t := item.updated(); !t.IsZero() {
+			out.Updated = t.Format(time.RFC3339)
+		}
+		for _, name := range item.Authors {
+			out.Authors = append(out.Authors, atomPerson{Name: name})
+		}
+		for _, c := range item.Categories {
+			out.Categories = append(out.Categories, atomCategory{Term: c})
+		}
+		if item.Source.Title != "" || item.Source.URL != "" {
+			out.Source = &atomOutSource{Title: item.Source.Title}
+			if item.Source.URL != "" {
+				out.Source.Links = []atomOutLink{{Href: item.Source.URL, Rel: "alternate"}}
+			}
+			// Readers show the author, so attribute items to their source
+			if len(out.Authors) == 0 && item.Source.Title != "" {
+				out.Authors = []atomPerson{{Name: item.Source.Title}}
+			}
+		}
+		doc.Entries = append(doc.Entries, out)
+	}
+	return doc
+}
@@ -0,0 +1,237 @@
+// Package fetch provides the HTTP client shared by all news sources. It
+// identifies itself with a proper User-Agent, checks status codes, retries
+// transient failures with exponential backoff and caps response sizes.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/utils"
+)
+
+// Fetcher downloads the body of a URL. Sources depend on this interface so
+// that tests can substitute canned responses.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// FetcherFunc adapts a function to the Fetcher interface
+type FetcherFunc func(ctx context.Context, url string) ([]byte, error)
+
+// Fetch calls f(ctx, url)
+func (f FetcherFunc) Fetch(ctx context.Context, url string) ([]byte, error) {
+	return f(ctx, url)
+}
+
+// Default is the fetcher used by sources that were not given one
+var Default Fetcher = New()
+
+// ErrTooLarge is returned when a response body exceeds the size limit
+var ErrTooLarge = errors.New("response body too large")
+
+// StatusError is returned for responses with a non-200 status code
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Response is a downloaded HTTP response
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Client is a Fetcher built on net/http
+type Client struct {
+	HTTPClient *http.Client
+	UserAgent  string
+	// MaxRetries is the number of times a transient failure is retried
+	MaxRetries int
+	// BaseDelay is the delay before the first retry; it doubles with
+	// every further retry up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MaxRetryAfter is the longest Retry-After the client waits for;
+	// servers asking for longer are not retried
+	MaxRetryAfter time.Duration
+	// MaxBodySize caps the size of response bodies in bytes
+	MaxBodySize int64
+}
+
+// DefaultUserAgent identifies HaberlerPlus to the sites it reads
+var DefaultUserAgent = fmt.Sprintf("HaberlerPlus/%s (+https://github.com/furkandogmus/HaberlerPlus)", utils.Version)
+
+// New creates a Client with sensible defaults
+func New() *Client {
+	return &Client{
+		HTTPClient:    &http.Client{},
+		UserAgent:     DefaultUserAgent,
+		MaxRetries:    3,
+		BaseDelay:     500 * time.Millisecond,
+		MaxDelay:      8 * time.Second,
+		MaxRetryAfter: 30 * time.Second,
+		MaxBodySize:   10 << 20,
+	}
+}
+
+// Fetch downloads url and returns its body. Responses other than
+// 200 OK are returned as a *StatusError.
+func (c *Client) Fetch(ctx context.Context, url string) ([]byte, error) {
+	resp, err := c.Get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode}
+	}
+	return resp.Body, nil
+}
+
+// Get performs a GET request with the extra header and returns the response
+// whatever its status code. Transient network errors, 429 and 5xx responses
+// are retried.
+func (c *Client) Get(ctx context.Context, url string, header http.Header) (*Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.get(ctx, url, header)
+		if attempt >= c.MaxRetries || !retryable(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+				if after > c.MaxRetryAfter {
+					return resp, err
+				}
+				delay = after
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err == nil {
+				err = ctx.Err()
+			}
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// get performs a single attempt of a request
+func (c *Client) get(ctx context.Context, url string, header http.Header) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	if req.Header.Get("Accept-Language") == "" {
+		req.Header.Set("Accept-Language", "tr-TR,tr;q=0.9,en;q=0.5")
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := readLimited(resp.Body, c.MaxBodySize)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", url, err)
+	}
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
+}
+
+// readLimited reads r fully, failing with ErrTooLarge beyond limit bytes
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, ErrTooLarge
+	}
+	return body, nil
+}
+
+// retryable reports whether a failed attempt is worth repeating
+func retryable(ctx context.Context, resp *Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return transient(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// transient reports whether a request error is likely to go away when the
+// request is repeated: timeouts, refused or reset connections and responses
+// cut short. Canceled requests and other errors, e.g. DNS failures or
+// invalid URLs, are final.
+func transient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// backoff returns the jittered delay before retry number attempt+1
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.BaseDelay << attempt
+	if delay <= 0 || delay > c.MaxDelay {
+		delay = c.MaxDelay
+	}
+	// Equal jitter: wait between half and all of the delay
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
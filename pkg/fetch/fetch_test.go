@@ -0,0 +1,113 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// reply is a canned response of a test server
+type reply struct {
+	status     int
+	retryAfter string
+}
+
+func TestGetRetries(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		replies    []reply
+		wantStatus int
+		wantTries  int
+	}{
+		{"success", []reply{{200, ""}}, 200, 1},
+		{"503 then success", []reply{{503, ""}, {503, ""}, {200, ""}}, 200, 3},
+		{"retries exhausted", []reply{{500, ""}, {502, ""}, {503, ""}, {504, ""}, {200, ""}}, 504, 4},
+		{"404 is final", []reply{{404, ""}, {200, ""}}, 404, 1},
+		{"403 is final", []reply{{403, ""}, {200, ""}}, 403, 1},
+		{"429 with Retry-After", []reply{{429, "0"}, {200, ""}}, 200, 2},
+		{"Retry-After as a date", []reply{{503, time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}, {200, ""}}, 200, 2},
+		{"Retry-After beyond MaxRetryAfter", []reply{{429, "60"}, {200, ""}}, 429, 1},
+		{"invalid Retry-After uses backoff", []reply{{503, "yakında"}, {200, ""}}, 200, 2},
+	} {
+		var tries atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := int(tries.Add(1)) - 1
+			rep := tt.replies[min(n, len(tt.replies)-1)]
+			if rep.retryAfter != "" {
+				w.Header().Set("Retry-After", rep.retryAfter)
+			}
+			w.WriteHeader(rep.status)
+		}))
+
+		resp, err := testClient().Get(context.Background(), server.URL, nil)
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: Get: %v", tt.name, err)
+			continue
+		}
+		if resp.StatusCode != tt.wantStatus || int(tries.Load()) != tt.wantTries {
+			t.Errorf("%s: Get = %d after %d tries, want %d after %d tries", tt.name, resp.StatusCode, tries.Load(), tt.wantStatus, tt.wantTries)
+		}
+	}
+}
+
+func TestGetCanceledDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := testClient()
+	client.BaseDelay = time.Minute
+	client.MaxDelay = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Get(ctx, server.URL, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestFetchStatusError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := testClient().Fetch(context.Background(), server.URL)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Fetch = %v, want a 404 *StatusError", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	for _, tt := range []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"0", 0, true},
+		{"120", 2 * time.Minute, true},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+		{"", 0, false},
+		{"-5", 0, false},
+		{"1.5", 0, false},
+		{"yarın", 0, false},
+	} {
+		got, ok := retryAfter(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// testClient returns a client that retries without noticeable delays
+func testClient() *Client {
+	client := New()
+	client.BaseDelay = time.Millisecond
+	client.MaxDelay = 4 * time.Millisecond
+	client.MaxRetryAfter = time.Second
+	return client
+}
@@ -0,0 +1,168 @@
+// Package filter selects news items by keywords or regular expressions
+// matched against their title, summary and URL.
+//
+// A pattern is either a keyword, matched as a case and diacritic
+// insensitive substring ("doviz" matches "Döviz"), or a regular expression
+// between slashes ("/dolar|euro/"), matched case insensitively against both
+// the original and the folded text. A "title:", "summary:" or "url:" prefix
+// restricts a pattern to one field, e.g. "title:deprem".
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// Field is a part of a news item patterns are matched against
+type Field string
+
+// Fields of a news item
+const (
+	Title   Field = "title"
+	Summary Field = "summary"
+	URL     Field = "url"
+)
+
+// allFields are the fields a pattern without a prefix is matched against
+var allFields = []Field{Title, Summary, URL}
+
+// Pattern is a parsed keyword or regular expression
+type Pattern struct {
+	raw     string
+	fields  []Field
+	keyword string
+	re      *regexp.Regexp
+}
+
+// Parse parses a pattern as described in the package documentation
+func Parse(pattern string) (*Pattern, error) {
+	p := &Pattern{raw: pattern, fields: allFields}
+
+	body := strings.TrimSpace(pattern)
+	if field, rest, ok := strings.Cut(body, ":"); ok {
+		switch f := Field(strings.ToLower(field)); f {
+		case Title, Summary, URL:
+			p.fields = []Field{f}
+			body = strings.TrimSpace(rest)
+		}
+	}
+
+	if len(body) >= 2 && strings.HasPrefix(body, "/") && strings.HasSuffix(body, "/") {
+		re, err := regexp.Compile("(?i)" + body[1:len(body)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		p.re = re
+		return p, nil
+	}
+
+	p.keyword = text.CollapseSpace(text.Fold(body))
+	if p.keyword == "" {
+		return nil, fmt.Errorf("empty pattern %q", pattern)
+	}
+	return p, nil
+}
+
+// String returns the pattern as it was written
+func (p *Pattern) String() string {
+	return p.raw
+}
+
+// Match reports whether the pattern matches any of its fields of item
+func (p *Pattern) Match(item sources.NewsItem) bool {
+	for _, field := range p.fields {
+		var value string
+		switch field {
+		case Title:
+			value = item.Title
+		case Summary:
+			value = item.Summary
+		case URL:
+			value = item.URL
+		}
+		if value != "" && p.matchText(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchText matches the pattern against a single field value
+func (p *Pattern) matchText(value string) bool {
+	folded := text.CollapseSpace(text.Fold(value))
+	if p.re != nil {
+		return p.re.MatchString(value) || p.re.MatchString(folded)
+	}
+	return strings.Contains(folded, p.keyword)
+}
+
+// Filter keeps the items matching any include pattern, or every item when
+// there are none, and drops the items matching any exclude pattern
+type Filter struct {
+	Include []*Pattern
+	Exclude []*Pattern
+}
+
+// New parses the include and exclude patterns into a Filter
+func New(include, exclude []string) (*Filter, error) {
+	f := &Filter{}
+	for _, s := range include {
+		p, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		f.Include = append(f.Include, p)
+	}
+	for _, s := range exclude {
+		p, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		f.Exclude = append(f.Exclude, p)
+	}
+	return f, nil
+}
+
+// Empty reports whether the filter keeps every item
+func (f *Filter) Empty() bool {
+	return f == nil || len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Match reports whether the filter keeps item
+func (f *Filter) Match(item sources.NewsItem) bool {
+	if f.Empty() {
+		return true
+	}
+	for _, p := range f.Exclude {
+		if p.Match(item) {
+			return false
+		}
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, p := range f.Include {
+		if p.Match(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns the items the filter keeps
+func (f *Filter) Apply(items []sources.NewsItem) []sources.NewsItem {
+	if f.Empty() {
+		return items
+	}
+	var kept []sources.NewsItem
+	for _, item := range items {
+		if f.Match(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,136 @@
+package output
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/feed"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+// Homepage is the link of the feeds written by WriteFeed
+const Homepage = "https://github.com/furkandogmus/HaberlerPlus"
+
+// FeedID returns the Atom id of the feed of a selection, e.g.
+// "tag:github.com,2024:furkandogmus/HaberlerPlus/feed/ntv/ekonomi" for the
+// parts "NTV" and "Ekonomi". Empty parts are skipped; without any the feed
+// is that of all news.
+func FeedID(parts ...string) string {
+	var ids []string
+	for _, part := range parts {
+		if id := sources.MakeID(part); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		ids = []string{"all"}
+	}
+	return feed.TagPrefix + "feed/" + strings.Join(ids, "/")
+}
+
+// NewFeed turns records into an aggregated feed in the given format, RSS or
+// Atom, identified by id. Every item names the source it was taken from, and
+// the title names the source and category when all items share them. An
+// empty id is derived from these too.
+func NewFeed(format Format, id string, records []Record) *feed.Feed {
+	source, category := shared(records)
+	if id == "" {
+		id = FeedID(source, category)
+	}
+	f := &feed.Feed{
+		Format: feed.RSS2,
+		ID:     id,
+		Title:  feedTitle(source, category),
+		Link:   Homepage,
+		Items:  make([]feed.Item, 0, len(records)),
+	}
+	if format == Atom {
+		f.Format = feed.Atom
+	}
+	f.Description = f.Title + " haberleri"
+
+	homepages := make(map[string]string)
+	for _, r := range records {
+		item := feed.Item{
+			ID:      r.ID,
+			Title:   r.Title,
+			Link:    r.URL,
+			Summary: r.Summary,
+			Source:  feed.Source{Title: r.Source, URL: sourceHomepage(homepages, r)},
+		}
+		if r.Category != "" {
+			item.Categories = []string{r.Category}
+		}
+		if r.ImageURL != "" {
+			item.Enclosures = []feed.Enclosure{{URL: r.ImageURL}}
+		}
+		item.Published, _ = time.Parse(time.RFC3339, r.PublishedAt)
+		f.Items = append(f.Items, item)
+	}
+	return f
+}
+
+// WriteFeed writes records as an RSS or Atom feed identified by id, see
+// NewFeed
+func WriteFeed(w io.Writer, format Format, id string, records []Record) error {
+	return feed.Write(w, NewFeed(format, id, records))
+}
+
+// shared returns the source and category all records share; each is empty
+// when they differ
+func shared(records []Record) (source, category string) {
+	if len(records) == 0 {
+		return "", ""
+	}
+	source, category = records[0].Source, records[0].Category
+	for _, r := range records[1:] {
+		if r.Source != source {
+			source = ""
+		}
+		if r.Category != category {
+			category = ""
+		}
+	}
+	return source, category
+}
+
+// feedTitle names an aggregated feed after the source and category of its
+// items, e.g. "HaberlerPlus: NTV - Ekonomi"
+func feedTitle(source, category string) string {
+	title := "HaberlerPlus"
+	switch {
+	case source != "" && category != "":
+		return title + ": " + source + " - " + category
+	case source != "":
+		return title + ": " + source
+	case category != "":
+		return title + ": " + category
+	default:
+		return title
+	}
+}
+
+// sourceHomepage returns the homepage of the source of a record as
+// registered, or the site the record links to. Found homepages are kept in
+// known by source name.
+func sourceHomepage(known map[string]string, r Record) string {
+	if homepage, ok := known[r.Source]; ok {
+		return homepage
+	}
+	homepage := ""
+	for _, entry := range sources.List() {
+		if entry.Name == r.Source {
+			homepage = entry.Homepage
+			break
+		}
+	}
+	if homepage == "" {
+		if u, err := url.Parse(r.URL); err == nil && u.Host != "" {
+			homepage = u.Scheme + "://" + u.Host + "/"
+		}
+	}
+	known[r.Source] = homepage
+	return homepage
+}
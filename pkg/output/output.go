@@ -0,0 +1,138 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+// Format is a machine-readable output format
+type Format string
+
+// Supported output formats. Text is the default, colored terminal listing
+// which is rendered by the CLI itself.
+const (
+	Text   Format = "text"
+	JSON   Format = "json"
+	NDJSON Format = "ndjson"
+	CSV    Format = "csv"
+	TSV    Format = "tsv"
+	// RSS and Atom write an aggregated feed, see NewFeed
+	RSS  Format = "rss"
+	Atom Format = "atom"
+)
+
+// Formats lists all supported output formats
+var Formats = []Format{Text, JSON, NDJSON, CSV, TSV, RSS, Atom}
+
+// ParseFormat parses the name of an output format
+func ParseFormat(name string) (Format, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return Text, nil
+	}
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %q", name)
+}
+
+// Record is the stable serialized form of a news item.
+// Field names and their order are part of the output schema.
+type Record struct {
+	Source      string `json:"source"`
+	Category    string `json:"category"`
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Summary     string `json:"summary"`
+	ImageURL    string `json:"image_url"`
+	PublishedAt string `json:"published_at"`
+	FetchedAt   string `json:"fetched_at"`
+}
+
+// header is the CSV/TSV header row, matching the Record field order
+var header = []string{"source", "category", "id", "title", "url", "summary", "image_url", "published_at", "fetched_at"}
+
+func (r Record) fields() []string {
+	return []string{r.Source, r.Category, r.ID, r.Title, r.URL, r.Summary, r.ImageURL, r.PublishedAt, r.FetchedAt}
+}
+
+// NewRecords converts news items into records. Publication and fetch times
+// are formatted as RFC 3339 and left empty when unknown.
+func NewRecords(items []sources.NewsItem) []Record {
+	records := make([]Record, 0, len(items))
+	for _, item := range items {
+		records = append(records, Record{
+			Source:      item.Source,
+			Category:    item.Category,
+			ID:          item.ID,
+			Title:       item.Title,
+			URL:         item.URL,
+			Summary:     item.Summary,
+			ImageURL:    item.ImageURL,
+			PublishedAt: formatTime(item.PublishedAt),
+			FetchedAt:   formatTime(item.FetchedAt),
+		})
+	}
+	return records
+}
+
+// formatTime formats t as RFC 3339, or returns "" for the zero time
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Write serializes the records to w in the given format. Feeds are
+// identified by the source and category of their items; use WriteFeed to
+// identify them by what was selected instead.
+func Write(w io.Writer, format Format, records []Record) error {
+	switch format {
+	case JSON:
+		if records == nil {
+			records = []Record{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(records)
+	case NDJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case CSV, TSV:
+		cw := csv.NewWriter(w)
+		if format == TSV {
+			cw.Comma = '\t'
+		}
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		for _, r := range records {
+			if err := cw.Write(r.fields()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case RSS, Atom:
+		return WriteFeed(w, format, "", records)
+	default:
+		return fmt.Errorf("output format %q cannot be serialized", format)
+	}
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/cache"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+// DefaultTTL is how long fetched news are served from memory before the
+// source is asked again
+const DefaultTTL = 2 * time.Minute
+
+// errorTTL is how long a failed fetch is remembered, so that a broken
+// source is not asked again on every request
+const errorTTL = 15 * time.Second
+
+// entry is the latest fetch of a source category
+type entry struct {
+	items   []sources.NewsItem
+	err     error
+	expires time.Time
+	// done is closed when the fetch has completed
+	done chan struct{}
+}
+
+// memo keeps the latest fetch of every source category in memory, so that
+// concurrent and repeated requests share a single fetch per TTL
+type memo struct {
+	ttl     time.Duration
+	timeout time.Duration
+
+	mu      sync.Mutex
+	entries map[memoKey]*entry
+}
+
+type memoKey struct {
+	source   string
+	category int
+}
+
+func newMemo(ttl, timeout time.Duration) *memo {
+	return &memo{ttl: ttl, timeout: timeout, entries: make(map[memoKey]*entry)}
+}
+
+// cached is a news source whose fetches go through a memo
+type cached struct {
+	sources.NewsSource
+	memo *memo
+}
+
+// FetchNews returns the remembered news of the category while they are
+// fresh and fetches them otherwise. The fetch itself is not tied to ctx, as
+// other requests may be waiting for it, but waiting for it is.
+func (c *cached) FetchNews(ctx context.Context, categoryIndex int) ([]sources.NewsItem, error) {
+	m := c.memo
+	key := memoKey{c.Name(), categoryIndex}
+
+	m.mu.Lock()
+	e, ok := m.entries[key]
+	if !ok || e.expired() {
+		previous := e
+		e = &entry{done: make(chan struct{})}
+		m.entries[key] = e
+		go m.fetch(c.NewsSource, categoryIndex, e, previous)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-e.done:
+		return e.items, e.err
+	case <-ctx.Done():
+		err := &sources.FetchError{Kind: sources.ErrNetwork, Source: c.Name(), Err: ctx.Err()}
+		// The index is only checked by the fetch, which may still be running
+		if categories := c.Categories(); categoryIndex >= 0 && categoryIndex < len(categories) {
+			err.Category = categories[categoryIndex]
+		}
+		return nil, err
+	}
+}
+
+// expired reports whether a completed fetch should be repeated
+func (e *entry) expired() bool {
+	select {
+	case <-e.done:
+		return time.Now().After(e.expires)
+	default:
+		return false
+	}
+}
+
+// fetch fills in e. When the fetch fails the news of the previous fetch are
+// served instead, if there were any.
+func (m *memo) fetch(source sources.NewsSource, categoryIndex int, e, previous *entry) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	// The memo decides when news are stale, not the TTL of the disk cache
+	ctx = cache.Revalidate(ctx)
+
+	e.items, e.err = source.FetchNews(ctx, categoryIndex)
+	e.expires = time.Now().Add(m.ttl)
+	if e.err != nil {
+		e.expires = time.Now().Add(min(errorTTL, m.ttl))
+		if previous != nil && previous.err == nil {
+			e.items, e.err = previous.items, nil
+		}
+	}
+	close(e.done)
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+// blocking is a news source whose fetches wait until release is closed
+type blocking struct {
+	release chan struct{}
+}
+
+func (b *blocking) Name() string         { return "Bekleyen" }
+func (b *blocking) Categories() []string { return []string{"GÜNDEM"} }
+
+func (b *blocking) FetchNews(ctx context.Context, categoryIndex int) ([]sources.NewsItem, error) {
+	<-b.release
+	return nil, nil
+}
+
+func TestCachedCanceled(t *testing.T) {
+	source := &blocking{release: make(chan struct{})}
+	defer close(source.release)
+	c := &cached{NewsSource: source, memo: newMemo(DefaultTTL, time.Minute)}
+
+	for _, tt := range []struct {
+		categoryIndex int
+		wantCategory  string
+	}{
+		{0, "GÜNDEM"},
+		{1, ""},
+		{-1, ""},
+	} {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := c.FetchNews(ctx, tt.categoryIndex)
+		var fetchErr *sources.FetchError
+		if !errors.As(err, &fetchErr) || fetchErr.Category != tt.wantCategory || !errors.Is(err, context.Canceled) {
+			t.Errorf("FetchNews(%d) = %v, want a canceled fetch of %q", tt.categoryIndex, err, tt.wantCategory)
+		}
+	}
+}
@@ -0,0 +1,451 @@
+// Package server exposes the news sources as a JSON API over HTTP:
+//
+//	GET /sources                     registered sources
+//	GET /sources/{id}/categories     categories of a source
+//	GET /news?source=&category=      news of a source, category or topic
+//	GET /news/all                    news of every category of every source
+//	GET /feed.xml?source=&category=  the same news as an RSS or Atom feed
+//
+// Fetched news are kept in memory for a while, so that clients polling the
+// API do not cause a request to the news sites each time. Once they expire,
+// the news are fetched with cache.Revalidate, so that an on-disk cache in
+// front of the sources never serves copies older than that.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/filter"
+	"github.com/furkandogmus/HaberlerPlus/pkg/output"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+// DefaultTimeout limits the time spent fetching a single category
+const DefaultTimeout = 30 * time.Second
+
+// shutdownTimeout is how long open requests may take to complete when the
+// server is stopped
+const shutdownTimeout = 10 * time.Second
+
+// Options configures a Server
+type Options struct {
+	// Sources are the news sources served, GetAllSources when nil
+	Sources []sources.NewsSource
+	// Filter selects the news served, all of them when nil
+	Filter *filter.Filter
+	// TTL is how long fetched news are served from memory, DefaultTTL when zero
+	TTL time.Duration
+	// Timeout limits the fetch of a category, DefaultTimeout when zero
+	Timeout time.Duration
+	// Workers is the number of concurrent fetches of aggregate requests
+	Workers int
+	// ErrorLog receives errors writing responses, log.Default() when nil
+	ErrorLog *log.Logger
+}
+
+// Server serves the news API
+type Server struct {
+	opts    Options
+	sources []sources.NewsSource
+	ids     []string
+	mux     *http.ServeMux
+}
+
+// New creates a Server
+func New(opts Options) *Server {
+	if opts.Sources == nil {
+		opts.Sources = sources.GetAllSources()
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTTL
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.ErrorLog == nil {
+		opts.ErrorLog = log.Default()
+	}
+
+	s := &Server{opts: opts, mux: http.NewServeMux()}
+	m := newMemo(opts.TTL, opts.Timeout)
+	for _, source := range opts.Sources {
+		s.sources = append(s.sources, &cached{NewsSource: source, memo: m})
+		s.ids = append(s.ids, sourceID(source.Name()))
+	}
+
+	s.mux.HandleFunc("GET /sources", s.handleSources)
+	s.mux.HandleFunc("GET /sources/{id}/categories", s.handleCategories)
+	s.mux.HandleFunc("GET /news", s.handleNews)
+	s.mux.HandleFunc("GET /news/all", s.handleAll)
+	s.mux.HandleFunc("GET /feed.xml", s.handleFeed)
+	return s
+}
+
+// ServeHTTP serves an API request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves the API on addr until ctx is cancelled, then stops
+// accepting connections and waits for open requests to complete
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve is like ListenAndServe but accepts connections on ln
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+		ErrorLog:          s.opts.ErrorLog,
+	}
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// sourceID returns the registry id of the source with the given name
+func sourceID(name string) string {
+	for _, entry := range sources.List() {
+		if entry.Name == name {
+			return entry.ID
+		}
+	}
+	return sources.MakeID(name)
+}
+
+// find looks up a served source by id, name or 1-based index
+func (s *Server) find(query string) (int, error) {
+	for i, id := range s.ids {
+		if id == strings.ToLower(query) {
+			return i, nil
+		}
+	}
+	source, err := sources.FindSource(s.sources, query)
+	if err != nil {
+		return -1, err
+	}
+	for i := range s.sources {
+		if s.sources[i] == source {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("unknown news source %q", query)
+}
+
+// sourceInfo describes a source in the /sources response
+type sourceInfo struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Language   string `json:"language"`
+	Type       string `json:"type"`
+	Homepage   string `json:"homepage"`
+	Categories int    `json:"categories"`
+}
+
+// categoryInfo describes a category in the /sources/{id}/categories response
+type categoryInfo struct {
+	// Index is 1-based, like on the command line
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	// Topic is the canonical topic of the category, if any
+	Topic string `json:"topic,omitempty"`
+}
+
+// newsResponse is the response of the news endpoints
+type newsResponse struct {
+	Items  []output.Record `json:"items"`
+	Errors []errorInfo     `json:"errors,omitempty"`
+	// Unsupported lists the sources not covering a requested topic
+	Unsupported []unsupportedInfo `json:"unsupported,omitempty"`
+}
+
+// unsupportedInfo names a source that has no category of a topic
+type unsupportedInfo struct {
+	Source string `json:"source"`
+	Topic  string `json:"topic"`
+}
+
+// errorInfo describes a category that could not be fetched
+type errorInfo struct {
+	Source   string `json:"source"`
+	Category string `json:"category"`
+	// Kind classifies the error, see errorKind
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+func (s *Server) handleSources(w http.ResponseWriter, r *http.Request) {
+	list := make([]sourceInfo, 0, len(s.sources))
+	for i, source := range s.sources {
+		info := sourceInfo{ID: s.ids[i], Name: source.Name(), Categories: len(source.Categories())}
+		if entry, ok := sources.Lookup(s.ids[i]); ok {
+			info.Language = entry.Language
+			info.Type = string(entry.Type)
+			info.Homepage = entry.Homepage
+		}
+		list = append(list, info)
+	}
+	s.writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleCategories(w http.ResponseWriter, r *http.Request) {
+	i, err := s.find(r.PathValue("id"))
+	if err != nil {
+		s.writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	source := s.sources[i]
+	list := make([]categoryInfo, 0, len(source.Categories()))
+	for j, name := range source.Categories() {
+		list = append(list, categoryInfo{Index: j + 1, Name: name, Topic: string(sources.CategoryTopic(source, name))})
+	}
+	s.writeJSON(w, http.StatusOK, list)
+}
+
+// handleNews serves the news selected by the source and category
+// parameters, see selection
+func (s *Server) handleNews(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("source") == "" && q.Get("category") == "" {
+		s.writeError(w, http.StatusBadRequest, errors.New("source or category parameter required, see /news/all for all news"))
+		return
+	}
+	selected, unsupported, err := s.selection(q)
+	if err != nil {
+		s.writeError(w, http.StatusNotFound, err)
+		return
+	}
+	s.writeResults(w, sources.FetchResults(r.Context(), selected, s.opts.Workers), unsupported)
+}
+
+func (s *Server) handleAll(w http.ResponseWriter, r *http.Request) {
+	s.writeResults(w, sources.FetchAll(r.Context(), s.sources, s.opts.Workers), nil)
+}
+
+// handleFeed serves the news selected like for /news, or all news, as an
+// RSS feed, or as an Atom feed with format=atom
+func (s *Server) handleFeed(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := output.RSS
+	switch q.Get("format") {
+	case "", "rss":
+	case "atom":
+		format = output.Atom
+	default:
+		http.Error(w, fmt.Sprintf("unknown feed format %q", q.Get("format")), http.StatusBadRequest)
+		return
+	}
+
+	selected, _, err := s.selection(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	records, _, status := s.collect(sources.FetchResults(r.Context(), selected, s.opts.Workers))
+	if status != http.StatusOK {
+		http.Error(w, "news could not be fetched", status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	if format == output.Atom {
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(s.opts.TTL.Seconds())))
+	id := output.FeedID(append(q["source"], q["category"]...)...)
+	if err := output.WriteFeed(w, format, id, records); err != nil {
+		s.opts.ErrorLog.Printf("writing feed: %v", err)
+	}
+}
+
+// selection returns the categories selected by the source and category
+// parameters, each of which may be given several times: the named
+// categories of the named sources, every category of the named sources
+// when no category is given, the categories covering the named topics in
+// every source when no source is given, and everything when neither is.
+// Sources without a category of a requested topic are returned separately,
+// like with sources.FetchTopic.
+func (s *Server) selection(q url.Values) ([]sources.Result, []unsupportedInfo, error) {
+	selected := s.sources
+	if names := q["source"]; len(names) > 0 {
+		selected = nil
+		for _, name := range names {
+			i, err := s.find(name)
+			if err != nil {
+				return nil, nil, err
+			}
+			selected = append(selected, s.sources[i])
+		}
+	}
+
+	var results []sources.Result
+	var unsupported []unsupportedInfo
+	type category struct {
+		source sources.NewsSource
+		index  int
+	}
+	seen := make(map[category]bool)
+	add := func(source sources.NewsSource, index int) {
+		if !seen[category{source, index}] {
+			seen[category{source, index}] = true
+			results = append(results, sources.Result{Source: source, CategoryIndex: index, Category: source.Categories()[index]})
+		}
+	}
+
+	categories := q["category"]
+	for _, source := range selected {
+		if len(categories) == 0 {
+			for i := range source.Categories() {
+				add(source, i)
+			}
+			continue
+		}
+		for _, name := range categories {
+			if len(q["source"]) > 0 {
+				i, err := sources.FindCategory(source, name)
+				if err != nil {
+					return nil, nil, err
+				}
+				add(source, i)
+				continue
+			}
+			topic, ok := sources.ParseTopic(name)
+			if !ok {
+				return nil, nil, fmt.Errorf("unknown topic %q", name)
+			}
+			indexes := sources.TopicCategories(source, topic)
+			if len(indexes) == 0 {
+				unsupported = append(unsupported, unsupportedInfo{Source: source.Name(), Topic: string(topic)})
+			}
+			for _, i := range indexes {
+				add(source, i)
+			}
+		}
+	}
+	return results, unsupported, nil
+}
+
+// writeResults writes the filtered items of results along with the
+// categories that could not be fetched and the unsupported sources
+func (s *Server) writeResults(w http.ResponseWriter, results []sources.Result, unsupported []unsupportedInfo) {
+	records, failures, status := s.collect(results)
+	if status == http.StatusOK {
+		w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(s.opts.TTL.Seconds())))
+	}
+	s.writeJSON(w, status, newsResponse{Items: records, Errors: failures, Unsupported: unsupported})
+}
+
+// collect returns the filtered items of results, the categories that could
+// not be fetched and the status code of the response. Categories without
+// news are not errors; when every other category failed, the status code
+// tells why the first of them did.
+func (s *Server) collect(results []sources.Result) ([]output.Record, []errorInfo, int) {
+	records := []output.Record{}
+	var failures []errorInfo
+	var failed error
+	succeeded := false
+	for _, r := range results {
+		switch {
+		case r.Err == nil:
+			succeeded = true
+			records = append(records, output.NewRecords(s.opts.Filter.Apply(r.Items))...)
+		case errors.Is(r.Err, sources.ErrEmptyFeed):
+			succeeded = true
+		default:
+			if failed == nil {
+				failed = r.Err
+			}
+			failures = append(failures, errorInfo{
+				Source:   r.Source.Name(),
+				Category: r.Category,
+				Kind:     errorKind(r.Err),
+				Message:  r.Err.Error(),
+			})
+		}
+	}
+
+	if !succeeded && failed != nil {
+		return records, failures, errorStatus(failed)
+	}
+	return records, failures, http.StatusOK
+}
+
+// errorKind returns a stable name for the kind of a fetch error
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, sources.ErrNetwork):
+		return "network"
+	case errors.Is(err, sources.ErrHTTPStatus):
+		return "http_status"
+	case errors.Is(err, sources.ErrParse):
+		return "parse"
+	case errors.Is(err, sources.ErrUnknownCategory):
+		return "unknown_category"
+	default:
+		return "error"
+	}
+}
+
+// errorStatus returns the status code reporting a failed fetch: the news
+// site could not be reached in time, answered with an error or with
+// something that could not be read
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, sources.ErrUnknownCategory):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// writeJSON writes v as the JSON response body
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		s.opts.ErrorLog.Printf("writing response: %v", err)
+	}
+}
+
+// writeError writes an error response of the form {"error": "..."}
+func (s *Server) writeError(w http.ResponseWriter, status int, err error) {
+	s.writeJSON(w, status, map[string]string{"error": err.Error()})
+}
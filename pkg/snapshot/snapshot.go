@@ -0,0 +1,164 @@
+// Package snapshot keeps the last successfully fetched news of every source
+// category on disk so that they can be shown again without network access.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+)
+
+// ErrNotFound is returned when no snapshot of a category has been saved yet
+var ErrNotFound = errors.New("no offline copy")
+
+// Snapshot is the saved result of fetching one category of a source
+type Snapshot struct {
+	Source    string             `json:"source"`
+	Category  string             `json:"category"`
+	FetchedAt time.Time          `json:"fetched_at"`
+	Items     []sources.NewsItem `json:"items"`
+}
+
+// Store saves snapshots as JSON files in a directory
+type Store struct {
+	Dir string
+}
+
+// DefaultDir returns the default snapshot location,
+// e.g. ~/.cache/haberlerplus/snapshots on Linux.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "haberlerplus", "snapshots"), nil
+}
+
+// NewStore creates a Store in dir
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// path returns the file the snapshot of a source category is stored in
+func (s *Store) path(source, category string) string {
+	return filepath.Join(s.Dir, sources.MakeID(source), sources.MakeID(category)+".json")
+}
+
+// Save stores items as the latest snapshot of a source category
+func (s *Store) Save(source, category string, items []sources.NewsItem) error {
+	data, err := json.Marshal(Snapshot{
+		Source:    source,
+		Category:  category,
+		FetchedAt: time.Now(),
+		Items:     items,
+	})
+	if err != nil {
+		return err
+	}
+
+	path := s.path(source, category)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so readers never see partial
+	// snapshots; a unique name keeps concurrent saves from colliding
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// Load returns the latest snapshot of a source category
+func (s *Store) Load(source, category string) (*Snapshot, error) {
+	data, err := os.ReadFile(s.path(source, category))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("%s: %w", s.path(source, category), err)
+	}
+	return &snap, nil
+}
+
+// recorder is a news source that saves every successful fetch
+type recorder struct {
+	sources.NewsSource
+	store *Store
+}
+
+// Record wraps source so that every successful fetch is saved to store.
+// Failing to save a snapshot does not fail the fetch.
+func Record(store *Store, source sources.NewsSource) sources.NewsSource {
+	return &recorder{NewsSource: source, store: store}
+}
+
+// FetchNews fetches news from the wrapped source and saves them
+func (r *recorder) FetchNews(ctx context.Context, categoryIndex int) ([]sources.NewsItem, error) {
+	items, err := r.NewsSource.FetchNews(ctx, categoryIndex)
+	if err == nil {
+		r.store.Save(r.Name(), r.Categories()[categoryIndex], items)
+	}
+	return items, err
+}
+
+// offline is a news source that serves saved snapshots
+type offline struct {
+	sources.NewsSource
+	store *Store
+}
+
+// Offline wraps source so that news are read from the snapshots in store
+// instead of the network. Categories without a snapshot fail with an
+// error matching both sources.ErrNetwork and ErrNotFound.
+func Offline(store *Store, source sources.NewsSource) sources.NewsSource {
+	return &offline{NewsSource: source, store: store}
+}
+
+// FetchNews returns the latest snapshot of the category. Items saved without
+// a fetch time get the time of the snapshot.
+func (o *offline) FetchNews(ctx context.Context, categoryIndex int) ([]sources.NewsItem, error) {
+	categories := o.Categories()
+	if categoryIndex < 0 || categoryIndex >= len(categories) {
+		return nil, &sources.FetchError{Kind: sources.ErrUnknownCategory, Source: o.Name(), Err: fmt.Errorf("invalid category index: %d", categoryIndex)}
+	}
+
+	category := categories[categoryIndex]
+	snap, err := o.store.Load(o.Name(), category)
+	if err != nil {
+		return nil, &sources.FetchError{Kind: sources.ErrNetwork, Source: o.Name(), Category: category, Err: err}
+	}
+	if len(snap.Items) == 0 {
+		return nil, &sources.FetchError{Kind: sources.ErrEmptyFeed, Source: o.Name(), Category: category}
+	}
+	for i := range snap.Items {
+		if snap.Items[i].FetchedAt.IsZero() {
+			snap.Items[i].FetchedAt = snap.FetchedAt
+		}
+	}
+	return snap.Items, nil
+}
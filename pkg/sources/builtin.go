@@ -0,0 +1,38 @@
+package sources
+
+import (
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources/impl"
+)
+
+// Built-in news sources register themselves here, in the order they are
+// listed in the menu
+func init() {
+	// HTML-based sources
+	Register("gzt", func() NewsSource { return impl.NewGztSource() }, Metadata{
+		Name: "GZT.com", Language: "tr", Type: TypeHTML, Homepage: "https://www.gzt.com",
+	})
+	Register("hurriyet", func() NewsSource { return impl.NewHurriyetSource() }, Metadata{
+		Name: "Hurriyet.com.tr", Language: "tr", Type: TypeHTML, Homepage: "https://www.hurriyet.com.tr",
+	})
+	Register("sozcu", func() NewsSource { return impl.NewSozcuSource() }, Metadata{
+		Name: "Sozcu.com.tr", Language: "tr", Type: TypeHTML, Homepage: "https://www.sozcu.com.tr",
+		Topics: map[string]Topic{"FİNANS": TopicEconomy},
+	})
+	Register("milliyet", func() NewsSource { return impl.NewMilliyetSource() }, Metadata{
+		Name: "Milliyet.com.tr", Language: "tr", Type: TypeHTML, Homepage: "https://www.milliyet.com.tr",
+	})
+	Register("haberler", func() NewsSource { return impl.NewHaberlerComSource() }, Metadata{
+		Name: "Haberler.com", Language: "tr", Type: TypeHTML, Homepage: "https://www.haberler.com",
+	})
+
+	// RSS-based sources
+	Register("cnnturk", func() NewsSource { return impl.NewCNNTurkSource() }, Metadata{
+		Name: "CNN Türk", Language: "tr", Type: TypeRSS, Homepage: "https://www.cnnturk.com",
+	})
+	Register("ntv", func() NewsSource { return impl.NewNTVSource() }, Metadata{
+		Name: "NTV", Language: "tr", Type: TypeRSS, Homepage: "https://www.ntv.com.tr",
+	})
+	Register("haberturk", func() NewsSource { return impl.NewHaberturkSource() }, Metadata{
+		Name: "Habertürk", Language: "tr", Type: TypeRSS, Homepage: "https://www.haberturk.com",
+	})
+}
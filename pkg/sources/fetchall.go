@@ -0,0 +1,72 @@
+package sources
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultWorkers is the default number of concurrent fetches used by FetchAll
+const DefaultWorkers = 8
+
+// Result holds the outcome of fetching a single category of a news source
+type Result struct {
+	Source        NewsSource
+	CategoryIndex int
+	Category      string
+	Items         []NewsItem
+	Err           error
+}
+
+// FetchAll fetches every category of every given source concurrently with at
+// most workers fetches in flight. A failing category does not stop the others;
+// its error is recorded in the corresponding Result. Categories without news
+// have an error matching ErrEmptyFeed. Results are returned in source and
+// category order regardless of the order they completed in.
+func FetchAll(ctx context.Context, all []NewsSource, workers int) []Result {
+	var results []Result
+	for _, source := range all {
+		for i, category := range source.Categories() {
+			results = append(results, Result{
+				Source:        source,
+				CategoryIndex: i,
+				Category:      category,
+			})
+		}
+	}
+	return FetchResults(ctx, results, workers)
+}
+
+// FetchResults fills in the items and errors of the given results by
+// fetching their categories with at most workers fetches in flight. Only
+// the Source, CategoryIndex and Category fields of results need to be set.
+func FetchResults(ctx context.Context, results []Result, workers int) []Result {
+	if workers < 1 {
+		workers = DefaultWorkers
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(results)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				r := &results[j]
+				r.Items, r.Err = r.Source.FetchNews(ctx, r.CategoryIndex)
+			}
+		}()
+	}
+
+	for j := range results {
+		if ctx.Err() != nil {
+			r := &results[j]
+			r.Err = &FetchError{Kind: ErrNetwork, Source: r.Source.Name(), Category: r.Category, Err: ctx.Err()}
+			continue
+		}
+		jobs <- j
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,77 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors classifying why fetching news failed. Errors returned by
+// news sources match one of them with errors.Is.
+var (
+	// ErrNetwork means the source could not be reached or the response
+	// could not be read, including cancellation and timeouts
+	ErrNetwork = errors.New("network error")
+	// ErrHTTPStatus means the server answered with a non-200 status code
+	ErrHTTPStatus = errors.New("unexpected HTTP status")
+	// ErrParse means the response could not be parsed as a feed or page
+	ErrParse = errors.New("parse error")
+	// ErrEmptyFeed means the feed or page was fetched but contained no news
+	ErrEmptyFeed = errors.New("no news found")
+	// ErrUnknownCategory means the requested category does not exist
+	ErrUnknownCategory = errors.New("unknown category")
+)
+
+// FetchError describes a failed fetch of a source category
+type FetchError struct {
+	// Kind is one of the sentinel errors above
+	Kind     error
+	Source   string
+	Category string
+	URL      string
+	// StatusCode is set for ErrHTTPStatus errors
+	StatusCode int
+	// Err is the underlying error, if any
+	Err error
+}
+
+func (e *FetchError) Error() string {
+	msg := e.Kind.Error()
+	if e.StatusCode != 0 {
+		msg = fmt.Sprintf("%s %d", msg, e.StatusCode)
+	}
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Err)
+	} else if e.URL != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.URL)
+	}
+
+	switch {
+	case e.Source != "" && e.Category != "":
+		return fmt.Sprintf("%s - %s: %s", e.Source, e.Category, msg)
+	case e.Source != "":
+		return fmt.Sprintf("%s: %s", e.Source, msg)
+	default:
+		return msg
+	}
+}
+
+// Unwrap makes both the kind and the underlying error visible to errors.Is,
+// e.g. errors.Is(err, ErrNetwork) and errors.Is(err, context.DeadlineExceeded)
+func (e *FetchError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// sourceError records the source and category on a FetchError, wrapping
+// other errors as network errors
+func sourceError(err error, source, category string) error {
+	var fe *FetchError
+	if !errors.As(err, &fe) {
+		fe = &FetchError{Kind: ErrNetwork, Err: err}
+	}
+	fe.Source = source
+	fe.Category = category
+	return fe
+}
@@ -0,0 +1,69 @@
+package impl
+
+// CNNTurkConfig describes the CNN Türk RSS feeds. Their items often carry
+// only a numeric GUID, from which the article URL is rebuilt.
+var CNNTurkConfig = RSSConfig{
+	Name:       "CNN Türk",
+	Categories: []string{"GÜNDEM", "DÜNYA", "EKONOMİ", "SPOR", "SAĞLIK", "TEKNOLOJİ"},
+	FeedURLs: map[string]string{
+		"GÜNDEM":    "https://www.cnnturk.com/feed/rss/turkiye/news",
+		"DÜNYA":     "https://www.cnnturk.com/feed/rss/dunya/news",
+		"EKONOMİ":   "https://www.cnnturk.com/feed/rss/ekonomi/news",
+		"SPOR":      "https://www.cnnturk.com/feed/rss/spor/news",
+		"SAĞLIK":    "https://www.cnnturk.com/feed/rss/saglik/news",
+		"TEKNOLOJİ": "https://www.cnnturk.com/feed/rss/bilim-teknoloji/news",
+	},
+	FallbackURL: "https://www.cnnturk.com/{category}/{id}",
+	CategoryPaths: map[string]string{
+		"GÜNDEM":    "turkiye",
+		"DÜNYA":     "dunya",
+		"EKONOMİ":   "ekonomi",
+		"SPOR":      "spor",
+		"SAĞLIK":    "saglik",
+		"TEKNOLOJİ": "bilim-teknoloji",
+	},
+}
+
+// NTVConfig describes the NTV feeds, which are served in Atom format
+var NTVConfig = RSSConfig{
+	Name:       "NTV",
+	Categories: []string{"SON DAKİKA", "GÜNDEM", "DÜNYA", "EKONOMİ", "SPOR", "SAĞLIK", "TEKNOLOJİ"},
+	FeedURLs: map[string]string{
+		"SON DAKİKA": "https://www.ntv.com.tr/son-dakika.rss",
+		"GÜNDEM":     "https://www.ntv.com.tr/turkiye.rss",
+		"DÜNYA":      "https://www.ntv.com.tr/dunya.rss",
+		"EKONOMİ":    "https://www.ntv.com.tr/ekonomi.rss",
+		"SPOR":       "https://www.ntv.com.tr/spor.rss",
+		"SAĞLIK":     "https://www.ntv.com.tr/saglik.rss",
+		"TEKNOLOJİ":  "https://www.ntv.com.tr/teknoloji.rss",
+	},
+}
+
+// HaberturkConfig describes the Habertürk RSS feeds
+var HaberturkConfig = RSSConfig{
+	Name:       "Habertürk",
+	Categories: []string{"GÜNDEM", "DÜNYA", "EKONOMİ", "SPOR", "SAĞLIK", "TEKNOLOJİ"},
+	FeedURLs: map[string]string{
+		"GÜNDEM":    "https://www.haberturk.com/rss/kategori/gundem.xml",
+		"DÜNYA":     "https://www.haberturk.com/rss/kategori/dunya.xml",
+		"EKONOMİ":   "https://www.haberturk.com/rss/kategori/ekonomi.xml",
+		"SPOR":      "https://www.haberturk.com/rss/kategori/spor.xml",
+		"SAĞLIK":    "https://www.haberturk.com/rss/kategori/saglik.xml",
+		"TEKNOLOJİ": "https://www.haberturk.com/rss/kategori/teknoloji.xml",
+	},
+}
+
+// NewCNNTurkSource creates the CNN Türk news source
+func NewCNNTurkSource() *RSSSource {
+	return NewRSSSourceFromConfig(CNNTurkConfig)
+}
+
+// NewNTVSource creates the NTV news source
+func NewNTVSource() *RSSSource {
+	return NewRSSSourceFromConfig(NTVConfig)
+}
+
+// NewHaberturkSource creates the Habertürk news source
+func NewHaberturkSource() *RSSSource {
+	return NewRSSSourceFromConfig(HaberturkConfig)
+}
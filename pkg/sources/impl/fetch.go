@@ -0,0 +1,47 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/fetch"
+)
+
+// fetcherOrDefault returns f, or the shared fetch.Default when f is nil
+func fetcherOrDefault(f fetch.Fetcher) fetch.Fetcher {
+	if f == nil {
+		return fetch.Default
+	}
+	return f
+}
+
+// fetchBody downloads the given URL with f and returns the response body.
+// The request is bound to ctx so callers can cancel it or set a deadline.
+func fetchBody(ctx context.Context, f fetch.Fetcher, url string) ([]byte, error) {
+	body, err := fetcherOrDefault(f).Fetch(ctx, url)
+	if err != nil {
+		var statusErr *fetch.StatusError
+		if errors.As(err, &statusErr) {
+			return nil, &FetchError{Kind: ErrHTTPStatus, URL: url, StatusCode: statusErr.StatusCode}
+		}
+		return nil, &FetchError{Kind: ErrNetwork, URL: url, Err: err}
+	}
+	return body, nil
+}
+
+// fetchDocument downloads the given URL with f and parses it as an HTML document.
+func fetchDocument(ctx context.Context, f fetch.Fetcher, url string) (*goquery.Document, error) {
+	body, err := fetchBody(ctx, f, url)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, &FetchError{Kind: ErrParse, URL: url, Err: err}
+	}
+	return doc, nil
+}
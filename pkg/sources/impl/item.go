@@ -0,0 +1,100 @@
+package impl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/furkandogmus/HaberlerPlus/pkg/feed"
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// htmlSummary converts an HTML fragment, such as an RSS description, into
+// plain text and returns the first image it contains.
+func htmlSummary(fragment string) (summary, imageURL string) {
+	fragment = strings.TrimSpace(fragment)
+	if fragment == "" {
+		return "", ""
+	}
+	if !strings.Contains(fragment, "<") {
+		return fragment, ""
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return fragment, ""
+	}
+	return text.CollapseSpace(doc.Text()), selectionImage(doc.Selection)
+}
+
+// selectionImage returns the URL of the first image inside s, preferring
+// lazy-loading attributes over the placeholder src.
+func selectionImage(s *goquery.Selection) string {
+	img := s.Find("img").First()
+	for _, attr := range []string{"data-src", "data-original", "src"} {
+		if src, ok := img.Attr(attr); ok && src != "" && !strings.HasPrefix(src, "data:") {
+			return src
+		}
+	}
+	return ""
+}
+
+// selectionSummary returns the text of the first paragraph inside s unless it
+// merely repeats the title.
+func selectionSummary(s *goquery.Selection, title string) string {
+	summary := text.CollapseSpace(s.Find("p").First().Text())
+	if summary == title {
+		return ""
+	}
+	return summary
+}
+
+// selectionTime returns the time of the first <time datetime="..."> element inside s
+func selectionTime(s *goquery.Selection) time.Time {
+	datetime, _ := s.Find("time[datetime]").First().Attr("datetime")
+	t, _ := feed.ParseDate(datetime)
+	return t
+}
+
+// newsItemFromFeed builds a news item from a feed item using the given cleaned up title and URL
+func newsItemFromFeed(item feed.Item, title, url string) NewsItem {
+	description := item.Summary
+	if description == "" {
+		description = item.Content
+	}
+	summary, imageURL := htmlSummary(description)
+	if feedImage := item.ImageURL(); feedImage != "" {
+		imageURL = feedImage
+	}
+
+	id := item.ID
+	if id == "" {
+		id = url
+	}
+
+	return NewsItem{
+		Title:       title,
+		URL:         url,
+		Summary:     summary,
+		ImageURL:    imageURL,
+		PublishedAt: item.Published,
+		ID:          id,
+	}
+}
+
+// stampItems cleans up the title and summary of every item, records the
+// source, category and fetch time and fills in missing IDs with the URL.
+func stampItems(items []NewsItem, source, category string) []NewsItem {
+	now := time.Now()
+	for i := range items {
+		items[i].Title = text.Clean(items[i].Title)
+		items[i].Summary = text.Clean(items[i].Summary)
+		items[i].FetchedAt = now
+		items[i].Source = source
+		items[i].Category = category
+		if items[i].ID == "" {
+			items[i].ID = items[i].URL
+		}
+	}
+	return items
+}
@@ -0,0 +1,173 @@
+package impl
+
+// GztConfig describes the original gzt.com news source
+var GztConfig = SelectorConfig{
+	Name:        "GZT.com",
+	Categories:  []string{"POLITIKA", "DUNYA", "EKONOMI", "BILIM", "GUNCEL", "SAGLIK"},
+	URLTemplate: "https://www.gzt.com/{category}",
+	CategoryPaths: map[string]string{
+		"POLITIKA": "politika",
+		"DUNYA":    "dunya",
+		"EKONOMI":  "ekonomi",
+		"BILIM":    "bilim",
+		"GUNCEL":   "guncel",
+		"SAGLIK":   "saglik",
+	},
+	Rules: []SelectorRule{
+		{
+			Item:     ".feed-card-content.news-card-content",
+			Link:     []string{"a"},
+			TitleCut: "…..devamı",
+		},
+	},
+}
+
+// HurriyetConfig describes the hurriyet.com.tr news source
+var HurriyetConfig = SelectorConfig{
+	Name:        "Hurriyet.com.tr",
+	Categories:  []string{"GÜNDEM", "DÜNYA", "EKONOMİ", "TEKNOLOJİ", "SAĞLIK", "YAŞAM"},
+	URLTemplate: "https://www.hurriyet.com.tr/{category}/",
+	CategoryPaths: map[string]string{
+		"GÜNDEM":    "gundem",
+		"DÜNYA":     "dunya",
+		"EKONOMİ":   "ekonomi",
+		"TEKNOLOJİ": "teknoloji",
+		"SAĞLIK":    "saglik",
+		"YAŞAM":     "yasam",
+	},
+	Rules: []SelectorRule{
+		{
+			Item:  "div.category__list__item",
+			Title: []string{"h2"},
+			Link:  []string{"a[href]"},
+		},
+	},
+}
+
+// SozcuConfig describes the sozcu.com.tr news source
+var SozcuConfig = SelectorConfig{
+	Name:        "Sozcu.com.tr",
+	Categories:  []string{"GÜNDEM", "DÜNYA", "EKONOMİ", "SPOR", "FİNANS", "SAĞLIK"},
+	URLTemplate: "https://www.sozcu.com.tr/{category}/",
+	CategoryPaths: map[string]string{
+		"GÜNDEM":  "gundem",
+		"DÜNYA":   "dunya",
+		"EKONOMİ": "ekonomi",
+		"SPOR":    "spor",
+		"FİNANS":  "finans",
+		"SAĞLIK":  "saglik",
+	},
+	Rules: []SelectorRule{
+		{
+			Item:  ".list-content .row",
+			Title: []string{"span.d-block.fs-5.fw-semibold"},
+			Link:  []string{"a"},
+		},
+	},
+	Fallbacks: []SelectorRule{
+		{
+			Item:                "a",
+			MinTitleLength:      11,
+			MaxTitleLength:      199,
+			URLContainsCategory: true,
+		},
+	},
+}
+
+// MilliyetConfig describes the milliyet.com.tr news source
+var MilliyetConfig = SelectorConfig{
+	Name:        "Milliyet.com.tr",
+	Categories:  []string{"GÜNDEM", "DÜNYA", "EKONOMİ", "SPOR", "TEKNOLOJİ"},
+	URLTemplate: "https://www.milliyet.com.tr/{category}/",
+	CategoryPaths: map[string]string{
+		"GÜNDEM":    "gundem",
+		"DÜNYA":     "dunya",
+		"EKONOMİ":   "ekonomi",
+		"SPOR":      "spor",
+		"TEKNOLOJİ": "teknoloji",
+	},
+	Rules: []SelectorRule{
+		// Featured news in the slider
+		{Item: ".cat-slider__link", Title: []string{".cat-slider__title"}},
+		// Category cards
+		{Item: ".category-card", Title: []string{".category-card__head"}},
+	},
+	Fallbacks: []SelectorRule{
+		{Item: ".cat-list-card__link", Title: []string{".cat-list-card__title"}},
+	},
+}
+
+// HaberlerComConfig describes the haberler.com news source. The site
+// repeats stories across its blocks, so items are deduplicated.
+var HaberlerComConfig = SelectorConfig{
+	Name:        "Haberler.com",
+	Categories:  []string{"GÜNDEM", "DÜNYA", "EKONOMİ", "SPOR", "SAĞLIK", "TEKNOLOJİ"},
+	URLTemplate: "https://www.haberler.com/{category}/",
+	CategoryPaths: map[string]string{
+		"GÜNDEM":    "guncel",
+		"DÜNYA":     "dunya",
+		"EKONOMİ":   "ekonomi",
+		"SPOR":      "spor",
+		"SAĞLIK":    "saglik",
+		"TEKNOLOJİ": "teknoloji",
+	},
+	Rules: []SelectorRule{
+		// Featured news in the main slider
+		{
+			Item:                ".new3slide",
+			Title:               []string{".new3caption h2"},
+			Link:                []string{"a"},
+			URLContainsCategory: true,
+		},
+		// Card grid of the main content area
+		{
+			Item:                ".new3card",
+			Title:               []string{".new3card-body h3"},
+			Link:                []string{"a"},
+			URLContainsCategory: true,
+		},
+	},
+	MinItems: 10,
+	Fallbacks: []SelectorRule{
+		// Older page layout
+		{
+			Item:                "div.hblnBox, article.box, .news-item",
+			Title:               []string{"a.hblnTitle, h3, .news-title"},
+			Link:                []string{"a"},
+			URLContainsCategory: true,
+		},
+		// Any link into the category
+		{
+			Item:                "a[href*='{category}']",
+			MinTitleLength:      11,
+			MaxTitleLength:      199,
+			URLContainsCategory: true,
+		},
+	},
+	Dedupe: true,
+}
+
+// NewGztSource creates the GZT.com news source
+func NewGztSource() *SelectorSource {
+	return NewSelectorSource(GztConfig)
+}
+
+// NewHurriyetSource creates the Hürriyet news source
+func NewHurriyetSource() *SelectorSource {
+	return NewSelectorSource(HurriyetConfig)
+}
+
+// NewSozcuSource creates the Sözcü news source
+func NewSozcuSource() *SelectorSource {
+	return NewSelectorSource(SozcuConfig)
+}
+
+// NewMilliyetSource creates the Milliyet news source
+func NewMilliyetSource() *SelectorSource {
+	return NewSelectorSource(MilliyetConfig)
+}
+
+// NewHaberlerComSource creates the Haberler.com news source
+func NewHaberlerComSource() *SelectorSource {
+	return NewSelectorSource(HaberlerComConfig)
+}
@@ -1,198 +1,155 @@
 package impl
 
 import (
-	"encoding/xml"
-	"io/ioutil"
-	"net/http"
+	"context"
+	"fmt"
+	"net/url"
 	"strings"
-	"time"
-)
-
-// RSSItem represents a single item in an RSS feed
-type RSSItem struct {
-	Title       string `xml:"title"`
-	Link        string `xml:"link"`
-	Description string `xml:"description"`
-	PubDate     string `xml:"pubDate"`
-	GUID        struct {
-		Value       string `xml:",chardata"`
-		IsPermaLink string `xml:"isPermaLink,attr"`
-	} `xml:"guid"`
-}
-
-// RSSChannel represents an RSS channel
-type RSSChannel struct {
-	Title       string    `xml:"title"`
-	Description string    `xml:"description"`
-	Link        string    `xml:"link"`
-	Items       []RSSItem `xml:"item"`
-}
-
-// RSS represents an RSS feed
-type RSS struct {
-	XMLName xml.Name    `xml:"rss"`
-	Channel RSSChannel  `xml:"channel"`
-}
-
-// AtomLink represents a link in an Atom feed
-type AtomLink struct {
-	Href string `xml:"href,attr"`
-	Rel  string `xml:"rel,attr"`
-}
 
-// AtomEntry represents an entry in an Atom feed
-type AtomEntry struct {
-	Title   string    `xml:"title"`
-	Link    AtomLink  `xml:"link"`
-	Content string    `xml:"content"`
-	ID      string    `xml:"id"`
-}
+	"github.com/furkandogmus/HaberlerPlus/pkg/feed"
+	"github.com/furkandogmus/HaberlerPlus/pkg/fetch"
+)
 
-// Atom represents an Atom feed
-type Atom struct {
-	XMLName xml.Name    `xml:"feed"`
-	Title   string      `xml:"title"`
-	Link    AtomLink    `xml:"link"`
-	Entries []AtomEntry `xml:"entry"`
+// DefaultMaxItems is the number of items an RSSSource returns per category
+// unless its configuration says otherwise
+const DefaultMaxItems = 30
+
+// RSSConfig describes a feed based news source as plain data
+type RSSConfig struct {
+	Name       string
+	Categories []string
+	// FeedURLs maps each category to the URL of its feed
+	FeedURLs map[string]string
+	// MaxItems caps the number of items returned per category,
+	// zero means DefaultMaxItems
+	MaxItems int
+	// FallbackURL is a template for the URL of items whose link is missing
+	// or not absolute. "{id}" is replaced with the item ID and "{category}"
+	// with the category's entry in CategoryPaths. Items without a usable
+	// link are skipped when it is empty.
+	FallbackURL   string
+	CategoryPaths map[string]string
 }
 
-// RSSSource implements a news source for RSS feeds
+// RSSSource implements a news source for RSS, Atom and JSON feeds
 type RSSSource struct {
-	name       string
-	categories []string
-	feedURLs   map[string]string
+	config  RSSConfig
+	fetcher fetch.Fetcher
 }
 
 // NewRSSSource creates a new RSSSource instance
 func NewRSSSource(name string, categories []string, feedURLs map[string]string) *RSSSource {
-	return &RSSSource{
-		name:       name,
-		categories: categories,
-		feedURLs:   feedURLs,
+	return NewRSSSourceFromConfig(RSSConfig{
+		Name:       name,
+		Categories: categories,
+		FeedURLs:   feedURLs,
+	})
+}
+
+// NewRSSSourceFromConfig creates a new RSSSource instance from its description
+func NewRSSSourceFromConfig(config RSSConfig) *RSSSource {
+	if config.MaxItems <= 0 {
+		config.MaxItems = DefaultMaxItems
 	}
+	return &RSSSource{config: config}
+}
+
+// SetFetcher makes the source download its feeds with f instead of fetch.Default
+func (r *RSSSource) SetFetcher(f fetch.Fetcher) {
+	r.fetcher = f
 }
 
 // Name returns the name of the news source
 func (r *RSSSource) Name() string {
-	return r.name
+	return r.config.Name
 }
 
 // Categories returns the available categories for this news source
 func (r *RSSSource) Categories() []string {
-	return r.categories
+	return r.config.Categories
 }
 
 // FetchNews fetches news items for the specified category
-func (r *RSSSource) FetchNews(categoryIndex int) ([]NewsItem, error) {
-	// Adjust for 0-based indexing
-	if categoryIndex >= 0 && categoryIndex < len(r.categories) {
-		// This is 0-based indexing, convert to 1-based for our internal use
-		categoryIndex++
-	}
-
-	if categoryIndex < 1 || categoryIndex > len(r.categories) {
-		
-		return []NewsItem{}, nil
+func (r *RSSSource) FetchNews(ctx context.Context, categoryIndex int) ([]NewsItem, error) {
+	if categoryIndex < 0 || categoryIndex >= len(r.config.Categories) {
+		return nil, &FetchError{Kind: ErrUnknownCategory, Source: r.config.Name, Err: fmt.Errorf("invalid category index: %d", categoryIndex)}
 	}
 
-	category := r.categories[categoryIndex-1]
-	feedURL, ok := r.feedURLs[category]
+	category := r.config.Categories[categoryIndex]
+	feedURL, ok := r.config.FeedURLs[category]
 	if !ok {
-	
-		return []NewsItem{}, nil
-	}
-
-	
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+		return nil, &FetchError{Kind: ErrUnknownCategory, Source: r.config.Name, Category: category, Err: fmt.Errorf("no feed configured")}
 	}
 
 	// Fetch the feed
-	resp, err := client.Get(feedURL)
+	body, err := fetchBody(ctx, r.fetcher, feedURL)
 	if err != nil {
-		return []NewsItem{}, nil
+		return nil, sourceError(err, r.config.Name, category)
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	// Parse the feed, whatever its format
+	parsed, err := feed.Parse(body)
 	if err != nil {
-		return []NewsItem{}, nil
+		return nil, &FetchError{Kind: ErrParse, Source: r.config.Name, Category: category, URL: feedURL, Err: err}
+	}
+	if len(parsed.Items) == 0 {
+		return nil, &FetchError{Kind: ErrEmptyFeed, Source: r.config.Name, Category: category, URL: feedURL}
 	}
 
-	
-	// Try to parse as RSS first
-	var rss RSS
-	err1 := xml.Unmarshal(body, &rss)
-	if err1 == nil && len(rss.Channel.Items) > 0 {
-			
-		// Process RSS items
-		maxItems := 30
-		if len(rss.Channel.Items) < maxItems {
-			maxItems = len(rss.Channel.Items)
+	// Process feed items
+	newsItems := make([]NewsItem, 0, min(len(parsed.Items), r.config.MaxItems))
+	for i, item := range parsed.Items {
+		if i >= r.config.MaxItems {
+			break
 		}
-		
-		newsItems := make([]NewsItem, 0, maxItems)
-		for i := 0; i < maxItems; i++ {
-			item := rss.Channel.Items[i]
-			
-			// Skip items with empty titles or links
-			if item.Title == "" || item.Link == "" {
-				continue
-			}
-			
-			// Clean up the title
-			title := strings.TrimSpace(item.Title)
-			title = strings.TrimPrefix(title, "<![CDATA[")
-			title = strings.TrimSuffix(title, "]]>")
-			
-			// Add the item to the list
-			newsItems = append(newsItems, NewsItem{
-				Title: title,
-				URL:   item.Link,
-			})
-			
-		
+
+		// Skip items with empty titles or links; CDATA sections are already
+		// unwrapped by the parser and the title is cleaned by stampItems
+		title := strings.TrimSpace(item.Title)
+		link := r.itemURL(item, feedURL, category)
+		if title == "" || link == "" {
+			continue
 		}
-		
-		return newsItems, nil
+
+		// Add the item to the list
+		newsItems = append(newsItems, newsItemFromFeed(item, title, link))
 	}
 
-	// If RSS parsing failed, try Atom
-	var atom Atom
-	err2 := xml.Unmarshal(body, &atom)
-	if err2 == nil && len(atom.Entries) > 0 {
-		
-		// Process Atom entries
-		maxItems := 30
-		if len(atom.Entries) < maxItems {
-			maxItems = len(atom.Entries)
-		}
-		
-		newsItems := make([]NewsItem, 0, maxItems)
-		for i := 0; i < maxItems; i++ {
-			entry := atom.Entries[i]
-			
-			// Skip entries with empty titles or links
-			if entry.Title == "" || entry.Link.Href == "" {
-				continue
-			}
-			
-			// Clean up the title
-			title := strings.TrimSpace(entry.Title)
-			
-			// Add the entry to the list
-			newsItems = append(newsItems, NewsItem{
-				Title: title,
-				URL:   entry.Link.Href,
-			})
-			
-	
+	if len(newsItems) == 0 {
+		return nil, &FetchError{Kind: ErrEmptyFeed, Source: r.config.Name, Category: category, URL: feedURL}
+	}
+	return stampItems(newsItems, r.config.Name, category), nil
+}
+
+// itemURL returns the absolute URL of a feed item. Relative links are
+// resolved against the feed URL unless a fallback template is configured,
+// in which case the template is used for any link that is not absolute.
+func (r *RSSSource) itemURL(item feed.Item, feedURL, category string) string {
+	link := strings.TrimSpace(item.Link)
+	if strings.HasPrefix(link, "http") {
+		return link
+	}
+
+	if r.config.FallbackURL != "" {
+		if item.ID == "" {
+			return ""
 		}
-		
-		return newsItems, nil
+		return strings.NewReplacer(
+			"{id}", item.ID,
+			"{category}", r.config.CategoryPaths[category],
+		).Replace(r.config.FallbackURL)
 	}
 
-	return []NewsItem{}, nil
+	if link == "" {
+		return ""
+	}
+	base, err := url.Parse(feedURL)
+	if err != nil {
+		return ""
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
 }
-
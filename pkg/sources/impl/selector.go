@@ -0,0 +1,273 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/furkandogmus/HaberlerPlus/pkg/feed"
+	"github.com/furkandogmus/HaberlerPlus/pkg/fetch"
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// SelectorConfig describes an HTML news source as a set of CSS selectors,
+// so that a change in a site's markup can be fixed by editing data.
+type SelectorConfig struct {
+	Name       string
+	Categories []string
+	// URLTemplate is the address of a category page. "{category}" is
+	// replaced with the category's entry in CategoryPaths.
+	URLTemplate   string
+	CategoryPaths map[string]string
+	// BaseURL is used to resolve relative links; it defaults to the page URL
+	BaseURL string
+	// Rules are all applied in order and their items are collected
+	Rules []SelectorRule
+	// Fallbacks are applied in order for as long as fewer than MinItems
+	// items have been found
+	Fallbacks []SelectorRule
+	// MinItems is the item count below which fallbacks are tried,
+	// zero means fallbacks are only used when nothing was found
+	MinItems int
+	// Dedupe drops items whose URL or title has already been seen
+	Dedupe bool
+}
+
+// SelectorRule extracts news items from the elements matching a selector.
+// Sub-selectors are evaluated within the item element and are chains: the
+// first selector yielding a value wins. An empty chain means the item
+// element itself. "{category}" in any selector is replaced with the
+// category path.
+type SelectorRule struct {
+	Item    string
+	Title   []string
+	Link    []string
+	Date    []string
+	Summary []string
+	Image   []string
+	// TitleCut drops everything from this marker on, e.g. "…..devamı"
+	TitleCut string
+	// MinTitleLength and MaxTitleLength filter out navigation links and
+	// other noise. Zero disables the respective check.
+	MinTitleLength int
+	MaxTitleLength int
+	// URLContainsCategory keeps only items whose URL contains the category path
+	URLContainsCategory bool
+}
+
+// SelectorSource implements a news source for HTML pages scraped with CSS selectors
+type SelectorSource struct {
+	config  SelectorConfig
+	fetcher fetch.Fetcher
+}
+
+// NewSelectorSource creates a new SelectorSource instance from its description
+func NewSelectorSource(config SelectorConfig) *SelectorSource {
+	return &SelectorSource{config: config}
+}
+
+// SetFetcher makes the source download its pages with f instead of fetch.Default
+func (s *SelectorSource) SetFetcher(f fetch.Fetcher) {
+	s.fetcher = f
+}
+
+// Name returns the name of the news source
+func (s *SelectorSource) Name() string {
+	return s.config.Name
+}
+
+// Categories returns the available categories for this news source
+func (s *SelectorSource) Categories() []string {
+	return s.config.Categories
+}
+
+// FetchNews fetches news items for the specified category
+func (s *SelectorSource) FetchNews(ctx context.Context, categoryIndex int) ([]NewsItem, error) {
+	categories := s.Categories()
+	if categoryIndex < 0 || categoryIndex >= len(categories) {
+		return nil, &FetchError{Kind: ErrUnknownCategory, Source: s.Name(), Err: fmt.Errorf("invalid category index: %d", categoryIndex)}
+	}
+
+	category := categories[categoryIndex]
+	categoryPath := s.config.CategoryPaths[category]
+	pageURL := strings.ReplaceAll(s.config.URLTemplate, "{category}", categoryPath)
+
+	doc, err := fetchDocument(ctx, s.fetcher, pageURL)
+	if err != nil {
+		return nil, sourceError(err, s.Name(), category)
+	}
+
+	baseURL := s.config.BaseURL
+	if baseURL == "" {
+		baseURL = pageURL
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, &FetchError{Kind: ErrParse, Source: s.Name(), Category: category, Err: err}
+	}
+
+	scrape := &scrape{
+		source:       s,
+		doc:          doc,
+		base:         base,
+		categoryPath: categoryPath,
+		seenURLs:     make(map[string]bool),
+		seenTitles:   make(map[string]bool),
+	}
+
+	for _, rule := range s.config.Rules {
+		scrape.apply(rule)
+	}
+
+	minItems := max(s.config.MinItems, 1)
+	for _, rule := range s.config.Fallbacks {
+		if len(scrape.items) >= minItems {
+			break
+		}
+		scrape.apply(rule)
+	}
+
+	if len(scrape.items) == 0 {
+		return nil, &FetchError{Kind: ErrEmptyFeed, Source: s.Name(), Category: category, URL: pageURL}
+	}
+	return stampItems(scrape.items, s.Name(), category), nil
+}
+
+// scrape holds the state of scraping a single category page
+type scrape struct {
+	source       *SelectorSource
+	doc          *goquery.Document
+	base         *url.URL
+	categoryPath string
+	seenURLs     map[string]bool
+	seenTitles   map[string]bool
+	items        []NewsItem
+}
+
+// apply collects the items matched by a rule
+func (sc *scrape) apply(rule SelectorRule) {
+	sc.doc.Find(sc.expand(rule.Item)).Each(func(i int, s *goquery.Selection) {
+		title := sc.text(s, rule.Title)
+		if rule.TitleCut != "" {
+			title = strings.TrimSpace(strings.Split(title, rule.TitleCut)[0])
+		}
+		if title == "" {
+			return
+		}
+		length := utf8.RuneCountInString(title)
+		if rule.MinTitleLength > 0 && length < rule.MinTitleLength {
+			return
+		}
+		if rule.MaxTitleLength > 0 && length > rule.MaxTitleLength {
+			return
+		}
+
+		href := sc.attr(s, rule.Link, "href")
+		if href == "" {
+			return
+		}
+		ref, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+		fullURL := sc.base.ResolveReference(ref).String()
+
+		if rule.URLContainsCategory && !strings.Contains(fullURL, sc.categoryPath) {
+			return
+		}
+		if sc.source.config.Dedupe {
+			if sc.seenURLs[fullURL] || sc.seenTitles[title] {
+				return
+			}
+			sc.seenURLs[fullURL] = true
+			sc.seenTitles[title] = true
+		}
+
+		item := NewsItem{
+			Title:       title,
+			URL:         fullURL,
+			Summary:     selectionSummary(s, title),
+			ImageURL:    selectionImage(s),
+			PublishedAt: selectionTime(s),
+		}
+		if len(rule.Summary) > 0 {
+			item.Summary = sc.text(s, rule.Summary)
+		}
+		if len(rule.Image) > 0 {
+			item.ImageURL = sc.attr(s, rule.Image, "data-src", "src")
+		}
+		if ref, err := url.Parse(item.ImageURL); err == nil && item.ImageURL != "" {
+			item.ImageURL = sc.base.ResolveReference(ref).String()
+		}
+		if len(rule.Date) > 0 {
+			item.PublishedAt = sc.date(s, rule.Date)
+		}
+
+		sc.items = append(sc.items, item)
+	})
+}
+
+// expand substitutes the category path into a selector
+func (sc *scrape) expand(selector string) string {
+	return strings.ReplaceAll(selector, "{category}", sc.categoryPath)
+}
+
+// find returns the first non-empty selection of a selector chain within s
+func (sc *scrape) find(s *goquery.Selection, chain []string, ok func(*goquery.Selection) bool) *goquery.Selection {
+	if len(chain) == 0 {
+		if ok(s) {
+			return s
+		}
+		return nil
+	}
+	for _, selector := range chain {
+		if found := s.Find(sc.expand(selector)); found.Length() > 0 && ok(found) {
+			return found
+		}
+	}
+	return nil
+}
+
+// text returns the whitespace collapsed text of a selector chain within s
+func (sc *scrape) text(s *goquery.Selection, chain []string) string {
+	found := sc.find(s, chain, func(f *goquery.Selection) bool {
+		return strings.TrimSpace(f.Text()) != ""
+	})
+	if found == nil {
+		return ""
+	}
+	return text.CollapseSpace(found.Text())
+}
+
+// attr returns the first non-empty attribute of a selector chain within s
+func (sc *scrape) attr(s *goquery.Selection, chain []string, attrs ...string) string {
+	value := ""
+	sc.find(s, chain, func(f *goquery.Selection) bool {
+		for _, name := range attrs {
+			if v, ok := f.First().Attr(name); ok && strings.TrimSpace(v) != "" {
+				value = strings.TrimSpace(v)
+				return true
+			}
+		}
+		return false
+	})
+	return value
+}
+
+// date parses the datetime or content attribute or the text of a selector chain within s
+func (sc *scrape) date(s *goquery.Selection, chain []string) time.Time {
+	found := sc.find(s, chain, func(*goquery.Selection) bool { return true })
+	if found == nil {
+		return time.Time{}
+	}
+	for _, candidate := range []string{found.AttrOr("datetime", ""), found.AttrOr("content", ""), found.First().Text()} {
+		if t, err := feed.ParseDate(candidate); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -1,7 +1,24 @@
 package impl
 
+import "time"
+
 // NewsItem represents a single news item
 type NewsItem struct {
 	Title string
 	URL   string
-} 
\ No newline at end of file
+	// Summary is a short plain text description of the news, if available
+	Summary string
+	// ImageURL points to a thumbnail or lead image, if available
+	ImageURL string
+	// PublishedAt is the publication time; it is zero when unknown
+	PublishedAt time.Time
+	// ID uniquely identifies the item within its source, e.g. an RSS GUID.
+	// It falls back to the URL when the source has no identifier of its own.
+	ID string
+	// Source is the name of the news source the item was fetched from
+	Source string
+	// Category is the name of the source category the item was fetched from
+	Category string
+	// FetchedAt is the time the item was downloaded from its source
+	FetchedAt time.Time
+}
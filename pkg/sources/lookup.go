@@ -0,0 +1,85 @@
+package sources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// matchName returns the index of the name in names matching query.
+// A query is either a 1-based index or a name; names are compared case and
+// diacritic insensitively and a unique prefix is accepted as well.
+func matchName(names []string, query string) (int, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return -1, fmt.Errorf("empty name")
+	}
+
+	if n, err := strconv.Atoi(query); err == nil {
+		if n < 1 || n > len(names) {
+			return -1, fmt.Errorf("index %d out of range 1-%d", n, len(names))
+		}
+		return n - 1, nil
+	}
+
+	folded := text.FoldName(query)
+	prefixMatch := -1
+	prefixCount := 0
+	for i, name := range names {
+		candidate := text.FoldName(name)
+		if candidate == folded {
+			return i, nil
+		}
+		if strings.HasPrefix(candidate, folded) {
+			prefixMatch = i
+			prefixCount++
+		}
+	}
+
+	switch prefixCount {
+	case 0:
+		return -1, fmt.Errorf("no match for %q", query)
+	case 1:
+		return prefixMatch, nil
+	default:
+		return -1, fmt.Errorf("%q is ambiguous", query)
+	}
+}
+
+// Find looks up an enabled news source by registry id, name or 1-based
+// index, the way users refer to sources on the command line.
+func Find(query string) (NewsSource, error) {
+	if entry, ok := Lookup(strings.ToLower(strings.TrimSpace(query))); ok && entry.Enabled {
+		return entry.New(), nil
+	}
+	return FindSource(GetAllSources(), query)
+}
+
+// FindSource looks up a news source by name or 1-based index
+func FindSource(all []NewsSource, query string) (NewsSource, error) {
+	names := make([]string, len(all))
+	for i, source := range all {
+		names[i] = source.Name()
+	}
+
+	i, err := matchName(names, query)
+	if err != nil {
+		return nil, fmt.Errorf("unknown news source: %w", err)
+	}
+	return all[i], nil
+}
+
+// FindCategory looks up a category of the source by name, 1-based index or
+// canonical topic and returns its 0-based index suitable for FetchNews.
+func FindCategory(source NewsSource, query string) (int, error) {
+	i, err := matchName(source.Categories(), query)
+	if err != nil {
+		if i, topicErr := findTopicCategory(source, query); topicErr == nil {
+			return i, nil
+		}
+		return -1, fmt.Errorf("unknown category for %s: %w", source.Name(), err)
+	}
+	return i, nil
+}
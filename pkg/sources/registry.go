@@ -0,0 +1,179 @@
+package sources
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// Type is the kind of a news source
+type Type string
+
+// Source types
+const (
+	TypeHTML Type = "html"
+	TypeRSS  Type = "rss"
+)
+
+// Metadata describes a registered news source
+type Metadata struct {
+	// ID is the stable identifier of the source, e.g. "ntv" or "cnnturk"
+	ID       string
+	Name     string
+	Language string
+	Type     Type
+	Homepage string
+	// Topics maps category names to canonical topics where the topic
+	// cannot be derived from the category name, see CategoryTopic
+	Topics map[string]Topic
+}
+
+// Factory creates a news source
+type Factory func() NewsSource
+
+// Entry is a news source in the registry
+type Entry struct {
+	Metadata
+	Enabled bool
+
+	factory Factory
+}
+
+// New creates the news source of the entry
+func (e Entry) New() NewsSource {
+	return e.factory()
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]*Entry)
+	// registryOrder keeps the order sources were registered in, which is
+	// the order they are listed and numbered in
+	registryOrder []string
+)
+
+// Register adds a news source to the registry under id. It panics if id is
+// empty or already registered, as built-in sources register from init.
+// The metadata's ID is set to id.
+func Register(id string, factory Factory, meta Metadata) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if id == "" || factory == nil {
+		panic("sources: Register called with empty id or nil factory")
+	}
+	if _, dup := registry[id]; dup {
+		panic(fmt.Sprintf("sources: Register called twice for %q", id))
+	}
+	register(id, factory, meta)
+}
+
+// Replace registers a news source under id, replacing any source already
+// registered under it while keeping its position and enabled state.
+func Replace(id string, factory Factory, meta Metadata) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if existing, ok := registry[id]; ok {
+		meta.ID = id
+		existing.Metadata = meta
+		existing.factory = factory
+		return
+	}
+	register(id, factory, meta)
+}
+
+func register(id string, factory Factory, meta Metadata) {
+	meta.ID = id
+	registry[id] = &Entry{Metadata: meta, Enabled: true, factory: factory}
+	registryOrder = append(registryOrder, id)
+}
+
+// Get creates the registered news source with the given id, whether it is
+// enabled or not
+func Get(id string) (NewsSource, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entry, ok := registry[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown news source id %q", id)
+	}
+	return entry.New(), nil
+}
+
+// Lookup returns the registry entry with the given id
+func Lookup(id string) (Entry, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entry, ok := registry[id]
+	if !ok {
+		return Entry{}, false
+	}
+	return *entry, true
+}
+
+// List returns all registered sources in registration order
+func List() []Entry {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entries := make([]Entry, 0, len(registryOrder))
+	for _, id := range registryOrder {
+		entries = append(entries, *registry[id])
+	}
+	return entries
+}
+
+// lookupName returns the registry entry of the source with the given name
+func lookupName(name string) (Entry, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, id := range registryOrder {
+		if entry := registry[id]; entry.Name == name {
+			return *entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// IDs returns the sorted ids of all registered sources
+func IDs() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	ids := append([]string{}, registryOrder...)
+	sort.Strings(ids)
+	return ids
+}
+
+// Enable marks a registered source as enabled
+func Enable(id string) error {
+	return setEnabled(id, true)
+}
+
+// Disable marks a registered source as disabled, hiding it from GetAllSources
+func Disable(id string) error {
+	return setEnabled(id, false)
+}
+
+func setEnabled(id string, enabled bool) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	entry, ok := registry[id]
+	if !ok {
+		return fmt.Errorf("unknown news source id %q", id)
+	}
+	entry.Enabled = enabled
+	return nil
+}
+
+// MakeID derives a source id from a source name, e.g. "CNN Türk" -> "cnnturk"
+func MakeID(name string) string {
+	return text.FoldName(name)
+}
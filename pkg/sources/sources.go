@@ -1,6 +1,8 @@
 package sources
 
 import (
+	"context"
+
 	"github.com/furkandogmus/HaberlerPlus/pkg/sources/impl"
 )
 
@@ -8,23 +10,33 @@ import (
 type NewsSource interface {
 	Name() string
 	Categories() []string
-	FetchNews(categoryIndex int) ([]NewsItem, error)
+	// FetchNews fetches the news of the category at categoryIndex.
+	// The fetch is aborted when ctx is cancelled or its deadline expires.
+	FetchNews(ctx context.Context, categoryIndex int) ([]NewsItem, error)
 }
 
 // NewsItem is an alias for impl.NewsItem
 type NewsItem = impl.NewsItem
 
-// GetAllSources returns all available news sources
+// GetAllSources returns all enabled news sources in registration order
 func GetAllSources() []NewsSource {
-	return []NewsSource{
-		NewGztSource(),
-		NewHurriyetSource(),
-		NewSozcuSource(),
-		NewMilliyetSource(),
-		NewHaberlerComSource(),
-		// New RSS-based sources
-		NewCNNTurkSource(),
-		NewNTVSource(),
-		NewHaberturkSource(),
+	var all []NewsSource
+	for _, entry := range List() {
+		if entry.Enabled {
+			all = append(all, entry.New())
+		}
 	}
-}
\ No newline at end of file
+	return all
+}
+
+// Errors returned by news sources, see impl.FetchError
+var (
+	ErrNetwork         = impl.ErrNetwork
+	ErrHTTPStatus      = impl.ErrHTTPStatus
+	ErrParse           = impl.ErrParse
+	ErrEmptyFeed       = impl.ErrEmptyFeed
+	ErrUnknownCategory = impl.ErrUnknownCategory
+)
+
+// FetchError is an alias for impl.FetchError
+type FetchError = impl.FetchError
@@ -0,0 +1,116 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// Topic is a canonical category shared by all sources, so that e.g. the
+// economy news of every source can be requested at once even though the
+// sources call the category "EKONOMİ", "EKONOMI" or "FİNANS".
+type Topic string
+
+// Canonical topics
+const (
+	TopicBreaking   Topic = "sondakika"
+	TopicAgenda     Topic = "gundem"
+	TopicPolitics   Topic = "politika"
+	TopicWorld      Topic = "dunya"
+	TopicEconomy    Topic = "ekonomi"
+	TopicSports     Topic = "spor"
+	TopicHealth     Topic = "saglik"
+	TopicTechnology Topic = "teknoloji"
+	TopicScience    Topic = "bilim"
+	TopicLife       Topic = "yasam"
+)
+
+// Topics lists the canonical topics in the order they are presented
+var Topics = []Topic{
+	TopicBreaking, TopicAgenda, TopicPolitics, TopicWorld, TopicEconomy,
+	TopicSports, TopicHealth, TopicTechnology, TopicScience, TopicLife,
+}
+
+// topicAliases maps folded category names to topics. Names equal to a
+// topic need no entry.
+var topicAliases = map[string]Topic{
+	"guncel":         TopicAgenda,
+	"turkiye":        TopicAgenda,
+	"siyaset":        TopicPolitics,
+	"bilimteknoloji": TopicTechnology,
+	"teknolojibilim": TopicTechnology,
+}
+
+// ParseTopic looks up a topic by name, case and diacritic insensitively,
+// e.g. "Ekonomi" or "SAĞLIK"
+func ParseTopic(name string) (Topic, bool) {
+	folded := text.FoldName(name)
+	for _, topic := range Topics {
+		if folded == string(topic) {
+			return topic, true
+		}
+	}
+	topic, ok := topicAliases[folded]
+	return topic, ok
+}
+
+// CategoryTopic returns the topic of a category of source, or "" if the
+// category has none. Mappings in the registry metadata of the source take
+// precedence over the topic derived from the category name.
+func CategoryTopic(source NewsSource, category string) Topic {
+	if entry, ok := lookupName(source.Name()); ok {
+		if topic, ok := entry.Topics[category]; ok {
+			return topic
+		}
+	}
+	topic, _ := ParseTopic(category)
+	return topic
+}
+
+// TopicCategories returns the 0-based indexes of the categories of source
+// belonging to topic; it is empty if the source does not cover the topic
+func TopicCategories(source NewsSource, topic Topic) []int {
+	var indexes []int
+	for i, category := range source.Categories() {
+		if CategoryTopic(source, category) == topic {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// FetchTopic fetches the categories belonging to topic of every given
+// source concurrently, like FetchAll. Sources that do not cover the topic
+// are returned separately.
+func FetchTopic(ctx context.Context, all []NewsSource, topic Topic, workers int) (results []Result, unsupported []NewsSource) {
+	for _, source := range all {
+		indexes := TopicCategories(source, topic)
+		if len(indexes) == 0 {
+			unsupported = append(unsupported, source)
+			continue
+		}
+		for _, i := range indexes {
+			results = append(results, Result{
+				Source:        source,
+				CategoryIndex: i,
+				Category:      source.Categories()[i],
+			})
+		}
+	}
+	return FetchResults(ctx, results, workers), unsupported
+}
+
+// findTopicCategory returns the first category of source belonging to the
+// topic named query
+func findTopicCategory(source NewsSource, query string) (int, error) {
+	topic, ok := ParseTopic(query)
+	if !ok {
+		return -1, fmt.Errorf("unknown topic %q", query)
+	}
+	indexes := TopicCategories(source, topic)
+	if len(indexes) == 0 {
+		return -1, fmt.Errorf("%s has no %s category", source.Name(), topic)
+	}
+	return indexes[0], nil
+}
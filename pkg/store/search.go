@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// Query describes a search over the stored articles
+type Query struct {
+	// Text is the search text; every word in it must start a word of the
+	// title or the summary. Matching is case and diacritic insensitive.
+	Text string
+	// Since limits the search to articles last seen after this time
+	Since time.Time
+	// Source limits the search to a source name, if set
+	Source string
+	// Category limits the search to a category name, if set
+	Category string
+	// Limit is the maximum number of results; 0 means no limit
+	Limit int
+}
+
+// Match is an article found by Search
+type Match struct {
+	*Article
+	// Score ranks the match; higher is better
+	Score float64
+}
+
+// Search returns the articles matching the query, best matches first.
+// Candidates are looked up in the full-text index, where every word has to
+// start a word of the title or summary; they are then ranked by how often
+// and where the words occur, weighted by how recently the article was
+// published.
+func (s *Store) Search(ctx context.Context, q Query) ([]Match, error) {
+	words := strings.Fields(text.Fold(q.Text))
+	match := matchExpression(words)
+	if match == "" {
+		return nil, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+articleColumns+` FROM articles
+		WHERE rowid IN (SELECT rowid FROM articles_fts WHERE articles_fts MATCH ?) AND last_seen >= ?`,
+		match, unixTime(q.Since))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	var matches []Match
+	for rows.Next() {
+		a, err := scanArticle(rows)
+		if err != nil {
+			return nil, err
+		}
+		if q.Source != "" && sources.MakeID(a.Source) != sources.MakeID(q.Source) {
+			continue
+		}
+		if q.Category != "" && sources.MakeID(a.Category) != sources.MakeID(q.Category) {
+			continue
+		}
+
+		relevance := relevance(words, text.Fold(a.Title), text.Fold(a.Summary))
+		if relevance == 0 {
+			continue
+		}
+		matches = append(matches, Match{Article: a, Score: relevance * recency(a, now)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Time().After(matches[j].Time())
+	})
+	if q.Limit > 0 && len(matches) > q.Limit {
+		matches = matches[:q.Limit]
+	}
+	return matches, nil
+}
+
+// matchExpression returns the FTS5 query finding the articles containing
+// every folded word as a word prefix, e.g. "enflasyon"* AND "oran"*, so
+// that Turkish suffixes as in "enflasyonu" still match. Words without
+// letters or digits are left out; the result is empty if none remain.
+func matchExpression(words []string) string {
+	var terms []string
+	for _, word := range words {
+		if !strings.ContainsFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			continue
+		}
+		terms = append(terms, `"`+strings.ReplaceAll(word, `"`, `""`)+`"*`)
+	}
+	return strings.Join(terms, " AND ")
+}
+
+// relevance scores folded title and summary against the folded search
+// words. It is 0 unless every word occurs; title hits count more than
+// summary hits and the whole phrase in the title counts extra.
+func relevance(words []string, title, summary string) float64 {
+	score := 0.0
+	for _, word := range words {
+		inTitle := strings.Count(title, word)
+		inSummary := strings.Count(summary, word)
+		if inTitle+inSummary == 0 {
+			return 0
+		}
+		score += 3*float64(inTitle) + float64(inSummary)
+	}
+	if len(words) > 1 && strings.Contains(title, strings.Join(words, " ")) {
+		score += 5
+	}
+	return score
+}
+
+// recency weights an article by age; a three day old article counts half
+// as much as a fresh one
+func recency(a *Article, now time.Time) float64 {
+	days := now.Sub(a.Time()).Hours() / 24
+	if days < 0 {
+		days = 0
+	}
+	return 1 / (1 + days/3)
+}
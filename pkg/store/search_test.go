@@ -0,0 +1,24 @@
+package store
+
+import "testing"
+
+func TestMatchExpression(t *testing.T) {
+	for _, tt := range []struct {
+		words []string
+		want  string
+	}{
+		{[]string{"enflasyon"}, `"enflasyon"*`},
+		{[]string{"enflasyon", "oran"}, `"enflasyon"* AND "oran"*`},
+		{[]string{`say"ı`}, `"say""ı"*`},
+		{[]string{"or", "and", "not"}, `"or"* AND "and"* AND "not"*`},
+		{[]string{"c++"}, `"c++"*`},
+		{[]string{"-", "enflasyon", `"`, "*"}, `"enflasyon"*`},
+		{[]string{"2026"}, `"2026"*`},
+		{[]string{"...", "?"}, ""},
+		{nil, ""},
+	} {
+		if got := matchExpression(tt.words); got != tt.want {
+			t.Errorf("matchExpression(%q) = %s, want %s", tt.words, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,469 @@
+// Package store keeps every fetched news item in an SQLite database so that
+// history, search and "new since last run" can be built on top of it. The
+// database is accessed through a pure Go driver and needs no cgo.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/text"
+)
+
+// ErrNotFound is returned when an article is not in the store
+var ErrNotFound = errors.New("article not found")
+
+// Article is a stored news item together with the times it was seen
+type Article struct {
+	sources.NewsItem
+	// Key identifies the article in the store, see Key
+	Key string
+	// FirstSeen is the first time the article was fetched
+	FirstSeen time.Time
+	// LastSeen is the latest time the article was fetched
+	LastSeen time.Time
+}
+
+// Time returns when the article was published, or first seen when its
+// publication time is unknown
+func (a *Article) Time() time.Time {
+	if a.PublishedAt.IsZero() {
+		return a.FirstSeen
+	}
+	return a.PublishedAt
+}
+
+// Store is an SQLite database of articles
+type Store struct {
+	db *sql.DB
+}
+
+// migration is a schema change; data, if set, fills in what it adds from
+// the existing rows in Go
+type migration struct {
+	sql  string
+	data func(tx *sql.Tx) error
+}
+
+// migrations are applied in order; PRAGMA user_version records how many
+// of them the database has seen
+var migrations = []migration{
+	{sql: `CREATE TABLE articles (
+		key          TEXT PRIMARY KEY,
+		id           TEXT NOT NULL,
+		url          TEXT NOT NULL,
+		title        TEXT NOT NULL,
+		summary      TEXT NOT NULL,
+		image_url    TEXT NOT NULL,
+		published_at INTEGER NOT NULL,
+		source       TEXT NOT NULL,
+		category     TEXT NOT NULL,
+		first_seen   INTEGER NOT NULL,
+		last_seen    INTEGER NOT NULL
+	);
+	CREATE INDEX articles_source ON articles (source, category);
+	CREATE INDEX articles_last_seen ON articles (last_seen);`},
+	{sql: `CREATE TABLE shown (
+		source   TEXT NOT NULL,
+		category TEXT NOT NULL,
+		key      TEXT NOT NULL,
+		shown_at INTEGER NOT NULL,
+		PRIMARY KEY (source, category, key)
+	);`},
+	// articles_fts indexes the folded title and summary of every article
+	// under the rowid of the article, see Search
+	{sql: `CREATE VIRTUAL TABLE articles_fts USING fts5(title, summary, tokenize = 'unicode61 remove_diacritics 2');`, data: indexArticles},
+	{sql: `CREATE TABLE read (
+		key     TEXT PRIMARY KEY,
+		read_at INTEGER NOT NULL
+	);`},
+}
+
+// DefaultPath returns the default database location,
+// e.g. ~/.local/share/haberlerplus/news.db on Linux.
+func DefaultPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "haberlerplus", "news.db"), nil
+}
+
+// dataDir returns the directory for user data: $XDG_DATA_HOME or
+// ~/.local/share on Unix systems, Application Support on macOS and
+// %LocalAppData% on Windows
+func dataDir() (string, error) {
+	switch runtime.GOOS {
+	case "darwin", "ios":
+		return os.UserConfigDir()
+	case "windows":
+		return os.UserCacheDir()
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
+
+// Open opens the database at path, creating and migrating it as needed
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	dsn := "file:" + path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer; serialize access within the process
+	db.SetMaxOpenConns(1)
+
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// migrate brings the schema up to date
+func (s *Store) migrate() error {
+	var version int
+	if err := s.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i].sql); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+		if data := migrations[i].data; data != nil {
+			if err := data(tx); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migration %d: %w", i+1, err)
+			}
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Upsert inserts the items or updates the articles they were seen as
+// before. FirstSeen and the source and category the article was first seen
+// in are kept, while the content and LastSeen are replaced.
+// Items are considered seen at their FetchedAt time, or now when unset.
+func (s *Store) Upsert(ctx context.Context, items []sources.NewsItem) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO articles (key, id, url, title, summary, image_url, published_at, source, category, first_seen, last_seen)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			id = excluded.id,
+			url = excluded.url,
+			title = excluded.title,
+			summary = excluded.summary,
+			image_url = excluded.image_url,
+			published_at = excluded.published_at,
+			first_seen = min(articles.first_seen, excluded.first_seen),
+			last_seen = max(articles.last_seen, excluded.last_seen)
+		RETURNING rowid`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, item := range items {
+		key := Key(item)
+		if key == "" {
+			continue
+		}
+		seen := item.FetchedAt
+		if seen.IsZero() {
+			seen = now
+		}
+		var rowid int64
+		err := stmt.QueryRowContext(ctx, key, item.ID, item.URL, item.Title, item.Summary, item.ImageURL,
+			unixTime(item.PublishedAt), item.Source, item.Category, seen.Unix(), seen.Unix()).Scan(&rowid)
+		if err != nil {
+			return err
+		}
+		if err := indexArticle(ctx, tx, rowid, item.Title, item.Summary); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// indexArticle replaces the search index entry of the article at rowid
+func indexArticle(ctx context.Context, tx *sql.Tx, rowid int64, title, summary string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM articles_fts WHERE rowid = ?`, rowid); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO articles_fts (rowid, title, summary) VALUES (?, ?, ?)`,
+		rowid, text.Fold(title), text.Fold(summary))
+	return err
+}
+
+// indexArticles adds every stored article to the search index
+func indexArticles(tx *sql.Tx) error {
+	rows, err := tx.Query(`SELECT rowid, title, summary FROM articles`)
+	if err != nil {
+		return err
+	}
+	type article struct {
+		rowid          int64
+		title, summary string
+	}
+	var articles []article
+	for rows.Next() {
+		var a article
+		if err := rows.Scan(&a.rowid, &a.title, &a.summary); err != nil {
+			rows.Close()
+			return err
+		}
+		articles = append(articles, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, a := range articles {
+		if err := indexArticle(context.Background(), tx, a.rowid, a.title, a.summary); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get returns the article stored under key
+func (s *Store) Get(ctx context.Context, key string) (*Article, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+articleColumns+` FROM articles WHERE key = ?`, key)
+	a, err := scanArticle(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return a, err
+}
+
+// Count returns the number of stored articles
+func (s *Store) Count(ctx context.Context) (int, error) {
+	var n int
+	err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM articles`).Scan(&n)
+	return n, err
+}
+
+// Unshown returns the items that have not been marked as shown in their
+// source and category yet. Items without a key are always unshown.
+func (s *Store) Unshown(ctx context.Context, items []sources.NewsItem) ([]sources.NewsItem, error) {
+	var unshown []sources.NewsItem
+	for _, item := range items {
+		if Key(item) == "" {
+			unshown = append(unshown, item)
+			continue
+		}
+		var n int
+		err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM shown WHERE source = ? AND category = ? AND key = ?`,
+			item.Source, item.Category, Key(item)).Scan(&n)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			unshown = append(unshown, item)
+		}
+	}
+	return unshown, nil
+}
+
+// MarkShown records that the items were shown in their source and category
+func (s *Store) MarkShown(ctx context.Context, items []sources.NewsItem) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().Unix()
+	for _, item := range items {
+		if Key(item) == "" {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO shown (source, category, key, shown_at) VALUES (?, ?, ?, ?)`,
+			item.Source, item.Category, Key(item), now)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// SetRead marks an item as read or unread
+func (s *Store) SetRead(ctx context.Context, item sources.NewsItem, read bool) error {
+	key := Key(item)
+	if key == "" {
+		return nil
+	}
+	if !read {
+		_, err := s.db.ExecContext(ctx, `DELETE FROM read WHERE key = ?`, key)
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT OR IGNORE INTO read (key, read_at) VALUES (?, ?)`, key, time.Now().Unix())
+	return err
+}
+
+// Read returns the keys of the items that have been marked as read
+func (s *Store) Read(ctx context.Context, items []sources.NewsItem) (map[string]bool, error) {
+	read := make(map[string]bool)
+	for _, item := range items {
+		key := Key(item)
+		if key == "" {
+			continue
+		}
+		var n int
+		if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM read WHERE key = ?`, key).Scan(&n); err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			read[key] = true
+		}
+	}
+	return read, nil
+}
+
+// articleColumns lists the columns read by scanArticle
+const articleColumns = `key, id, url, title, summary, image_url, published_at, source, category, first_seen, last_seen`
+
+// scanArticle reads an article selected with articleColumns
+func scanArticle(row interface{ Scan(...any) error }) (*Article, error) {
+	var a Article
+	var published, firstSeen, lastSeen int64
+	err := row.Scan(&a.Key, &a.ID, &a.URL, &a.Title, &a.Summary, &a.ImageURL, &published,
+		&a.Source, &a.Category, &firstSeen, &lastSeen)
+	if err != nil {
+		return nil, err
+	}
+	if published != 0 {
+		a.PublishedAt = time.Unix(published, 0)
+	}
+	a.FirstSeen = time.Unix(firstSeen, 0)
+	a.LastSeen = time.Unix(lastSeen, 0)
+	a.FetchedAt = a.LastSeen
+	return &a, nil
+}
+
+// unixTime returns t in seconds since the epoch, or 0 for the zero time
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// Key returns the key an item is stored under: its canonical URL, or its
+// ID when it has no URL.
+func Key(item sources.NewsItem) string {
+	if item.URL != "" {
+		return CanonicalURL(item.URL)
+	}
+	return strings.TrimSpace(item.ID)
+}
+
+// CanonicalURL normalizes an article URL so that the same article linked
+// in slightly different ways maps to one key. The scheme and host are
+// lowercased, default ports, fragments, tracking parameters and trailing
+// slashes are dropped and the remaining query parameters are sorted.
+func CanonicalURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	u.Host = strings.ToLower(u.Host)
+	u.Host = strings.TrimSuffix(u.Host, ":443")
+	u.Host = strings.TrimSuffix(u.Host, ":80")
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.User = nil
+	if len(u.Path) > 1 {
+		u.Path = strings.TrimRight(u.Path, "/")
+		u.RawPath = ""
+	}
+
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, "utm_") || lower == "fbclid" || lower == "gclid" {
+			query.Del(key)
+		}
+	}
+	// Encode sorts the parameters by key
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// recorder is a news source that stores every fetched item
+type recorder struct {
+	sources.NewsSource
+	store *Store
+}
+
+// Record wraps source so that every successfully fetched item is upserted
+// into store. Failing to store items does not fail the fetch.
+func Record(store *Store, source sources.NewsSource) sources.NewsSource {
+	return &recorder{NewsSource: source, store: store}
+}
+
+// FetchNews fetches news from the wrapped source and stores them
+func (r *recorder) FetchNews(ctx context.Context, categoryIndex int) ([]sources.NewsItem, error) {
+	items, err := r.NewsSource.FetchNews(ctx, categoryIndex)
+	if err == nil {
+		r.store.Upsert(context.WithoutCancel(ctx), items)
+	}
+	return items, err
+}
@@ -0,0 +1,131 @@
+// Package text normalizes the Turkish text found in news feeds and pages:
+// Turkish aware casing, diacritic folding for comparisons, HTML entity
+// decoding, whitespace collapsing and removal of "read more" markers.
+package text
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Lower lowercases s using Turkish rules, e.g. "IĞDIR" -> "ığdır"
+func Lower(s string) string {
+	return strings.ToLowerSpecial(unicode.TurkishCase, s)
+}
+
+// Upper uppercases s using Turkish rules, e.g. "istanbul" -> "İSTANBUL"
+func Upper(s string) string {
+	return strings.ToUpperSpecial(unicode.TurkishCase, s)
+}
+
+// diacritics maps lowercase letters with diacritics to plain ASCII
+var diacritics = strings.NewReplacer(
+	"ç", "c", "ğ", "g", "ı", "i", "ö", "o", "ş", "s", "ü", "u",
+	"â", "a", "î", "i", "û", "u",
+	"á", "a", "à", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o",
+	"ú", "u", "ù", "u",
+	"ñ", "n",
+)
+
+// Fold lowercases s and replaces letters with diacritics by their plain
+// ASCII counterparts so that text can be compared case and diacritic
+// insensitively, e.g. "Enflasyon DÜŞTÜ" -> "enflasyon dustu". Other
+// characters are kept.
+func Fold(s string) string {
+	folded := diacritics.Replace(Lower(s))
+	if !strings.ContainsFunc(folded, isMark) {
+		return folded
+	}
+	return strings.Map(func(r rune) rune {
+		if isMark(r) {
+			return -1
+		}
+		return r
+	}, folded)
+}
+
+// isMark reports whether r is a combining mark, such as the dot left
+// behind by lowercasing "İ" with non-Turkish rules
+func isMark(r rune) bool {
+	return unicode.Is(unicode.Mn, r)
+}
+
+// FoldName reduces a name to letters and digits compared case and
+// diacritic insensitively, e.g. "CNN Türk" -> "cnnturk"
+func FoldName(name string) string {
+	var b strings.Builder
+	for _, r := range Fold(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// DecodeEntities replaces HTML entities such as "&amp;" and "&#305;" with
+// the characters they stand for. Doubly escaped entities, which some feeds
+// contain, are decoded as well.
+func DecodeEntities(s string) string {
+	for i := 0; i < 2 && strings.Contains(s, "&"); i++ {
+		s = html.UnescapeString(s)
+	}
+	return s
+}
+
+// CollapseSpace trims s and replaces every run of whitespace, including
+// non-breaking spaces and newlines, with a single space
+func CollapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncation matches the "read more" markers sites append to cut off
+// titles and summaries, e.g. "…..devamı" or "Haberin devamı için tıklayın"
+var truncation = regexp.MustCompile(`(?i)\s*[.…]*\s*(?:` +
+	`(?:^|[.…]{2,})\s*devam[ıi]` +
+	`|(?:haberin\s+)?devam[ıi]\s+i[çc]in\s+t[ıi]kla(?:y[ıi]n(?:[ıi]z)?)?` +
+	`|devam[ıi]n[ıi]\s+oku(?:yun)?` +
+	`|\[\s*(?:\.{3}|…)\s*\]` +
+	`)\s*[.…>»]*\s*$`)
+
+// StripTruncation removes a trailing "read more" marker from s
+func StripTruncation(s string) string {
+	return truncation.ReplaceAllString(s, "")
+}
+
+// Clean normalizes a title or summary for display: it decodes HTML
+// entities, collapses whitespace and strips "read more" markers
+func Clean(s string) string {
+	return CollapseSpace(StripTruncation(CollapseSpace(DecodeEntities(s))))
+}
+
+// Wrap breaks s into lines of at most width characters at word boundaries.
+// Words longer than width are put on a line of their own.
+func Wrap(s string, width int) []string {
+	var lines []string
+	var line strings.Builder
+	length := 0
+	for _, word := range strings.Fields(s) {
+		n := utf8.RuneCountInString(word)
+		if length > 0 && length+1+n > width {
+			lines = append(lines, line.String())
+			line.Reset()
+			length = 0
+		}
+		if length > 0 {
+			line.WriteByte(' ')
+			length++
+		}
+		line.WriteString(word)
+		length += n
+	}
+	if length > 0 {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
@@ -0,0 +1,62 @@
+package text
+
+import "testing"
+
+func TestFold(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"İSTANBUL", "istanbul"},
+		{"IĞDIR", "igdir"},
+		{"ışık", "isik"},
+		{"Şişli'de Ağır Hasar", "sisli'de agir hasar"},
+		{"Enflasyon DÜŞTÜ", "enflasyon dustu"},
+		{"Çağrı Öğretmen", "cagri ogretmen"},
+		{"i̇stanbul", "istanbul"},
+		{"Kâğıt fiyatı", "kagit fiyati"},
+		{"Café 2026", "cafe 2026"},
+		{"", ""},
+	} {
+		if got := Fold(tt.in); got != tt.want {
+			t.Errorf("Fold(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFoldName(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"CNN Türk", "cnnturk"},
+		{"Habertürk", "haberturk"},
+		{"T24", "t24"},
+		{"NTV-Spor", "ntvspor"},
+	} {
+		if got := FoldName(tt.in); got != tt.want {
+			t.Errorf("FoldName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStripTruncation(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"Merkez Bankası faizi sabit tuttu…..devamı", "Merkez Bankası faizi sabit tuttu"},
+		{"Merkez Bankası faizi sabit tuttu... Devamı", "Merkez Bankası faizi sabit tuttu"},
+		{"Faiz kararı açıklandı. Haberin devamı için tıklayın", "Faiz kararı açıklandı"},
+		{"Faiz kararı açıklandı. Devamı için tıklayınız »", "Faiz kararı açıklandı"},
+		{"Faiz kararı açıklandı Devamını oku", "Faiz kararı açıklandı"},
+		{"Faiz kararı açıklandı [...]", "Faiz kararı açıklandı"},
+		{"Faiz kararı açıklandı […]", "Faiz kararı açıklandı"},
+		{"Devamı", ""},
+		// "devamı" inside a title is not a marker
+		{"Maçın devamı ertelendi", "Maçın devamı ertelendi"},
+		{"Ateşkesin devamı için görüşme", "Ateşkesin devamı için görüşme"},
+		{"Sözleşmenin devamı", "Sözleşmenin devamı"},
+	} {
+		if got := StripTruncation(tt.in); got != tt.want {
+			t.Errorf("StripTruncation(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
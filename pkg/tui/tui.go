@@ -0,0 +1,496 @@
+// Package tui implements the interactive terminal interface of HaberlerPlus:
+// a sidebar of sources and categories, the headlines of the selected
+// category and a preview of the selected headline.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/furkandogmus/HaberlerPlus/pkg/cache"
+	"github.com/furkandogmus/HaberlerPlus/pkg/filter"
+	"github.com/furkandogmus/HaberlerPlus/pkg/sources"
+	"github.com/furkandogmus/HaberlerPlus/pkg/store"
+)
+
+// DefaultRefresh is how often the shown category is fetched again
+const DefaultRefresh = 2 * time.Minute
+
+// Options configures the interface
+type Options struct {
+	Sources []sources.NewsSource
+	// Store remembers which articles were read, if set
+	Store *store.Store
+	// Filter selects the headlines shown, if set
+	Filter *filter.Filter
+	// Timeout bounds every fetch
+	Timeout time.Duration
+	// Refresh is how often the shown category is fetched again,
+	// DefaultRefresh when zero
+	Refresh time.Duration
+	// Offline marks the sources as serving saved snapshots; the preview
+	// then shows when every item was fetched
+	Offline bool
+}
+
+// Run shows the interface until the user quits
+func Run(opts Options) error {
+	if len(opts.Sources) == 0 {
+		return errors.New("no news sources")
+	}
+	_, err := tea.NewProgram(newModel(opts), tea.WithAltScreen()).Run()
+	return err
+}
+
+// pane is a focusable part of the screen
+type pane int
+
+const (
+	sidebarPane pane = iota
+	listPane
+)
+
+// category identifies a category of a source
+type category struct {
+	source, index int
+}
+
+// row is a line of the sidebar; header rows name a source and have an
+// index of -1
+type row struct {
+	category
+	label string
+}
+
+// fetchedMsg carries the result of fetching a category
+type fetchedMsg struct {
+	category category
+	items    []sources.NewsItem
+	read     map[string]bool
+	err      error
+}
+
+// tickMsg asks for the shown category to be refreshed
+type tickMsg struct{}
+
+// openedMsg reports the result of opening an article in the browser
+type openedMsg struct {
+	err error
+}
+
+// model is the state of the interface
+type model struct {
+	opts  Options
+	rows  []row
+	side  int
+	focus pane
+
+	items   map[category][]sources.NewsItem
+	errs    map[category]error
+	loading map[category]bool
+	cursors map[category]int
+	read    map[string]bool
+
+	status        string
+	width, height int
+}
+
+func newModel(opts Options) *model {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	if opts.Refresh <= 0 {
+		opts.Refresh = DefaultRefresh
+	}
+	m := &model{
+		opts:    opts,
+		items:   make(map[category][]sources.NewsItem),
+		errs:    make(map[category]error),
+		loading: make(map[category]bool),
+		cursors: make(map[category]int),
+		read:    make(map[string]bool),
+	}
+	for i, source := range opts.Sources {
+		m.rows = append(m.rows, row{category: category{i, -1}, label: source.Name()})
+		for j, name := range source.Categories() {
+			m.rows = append(m.rows, row{category: category{i, j}, label: name})
+		}
+	}
+	m.side = m.nextCategoryRow(0, 1)
+	return m
+}
+
+// Init starts fetching the first category and the refresh timer
+func (m *model) Init() tea.Cmd {
+	return tea.Batch(m.fetch(m.current(), false), m.tick())
+}
+
+// tick schedules the next refresh
+func (m *model) tick() tea.Cmd {
+	return tea.Tick(m.opts.Refresh, func(time.Time) tea.Msg { return tickMsg{} })
+}
+
+// current returns the category selected in the sidebar
+func (m *model) current() category {
+	return m.rows[m.side].category
+}
+
+// nextCategoryRow returns the first category row from i on in direction dir,
+// or the current row if there is none
+func (m *model) nextCategoryRow(i, dir int) int {
+	for ; i >= 0 && i < len(m.rows); i += dir {
+		if m.rows[i].index >= 0 {
+			return i
+		}
+	}
+	return m.side
+}
+
+// fetch loads a category in the background. A refresh asks the servers for
+// changes even when the cached copy is fresh.
+func (m *model) fetch(c category, refresh bool) tea.Cmd {
+	if c.index < 0 || m.loading[c] {
+		return nil
+	}
+	m.loading[c] = true
+	source := m.opts.Sources[c.source]
+	opts := m.opts
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+		defer cancel()
+		if refresh {
+			ctx = cache.Revalidate(ctx)
+		}
+
+		items, err := source.FetchNews(ctx, c.index)
+		if err != nil && !errors.Is(err, sources.ErrEmptyFeed) {
+			return fetchedMsg{category: c, err: err}
+		}
+		items = opts.Filter.Apply(items)
+
+		var read map[string]bool
+		if opts.Store != nil {
+			read, _ = opts.Store.Read(ctx, items)
+		}
+		return fetchedMsg{category: c, items: items, read: read}
+	}
+}
+
+// selected returns the headline under the cursor, if any
+func (m *model) selected() (sources.NewsItem, bool) {
+	c := m.current()
+	items := m.items[c]
+	if len(items) == 0 {
+		return sources.NewsItem{}, false
+	}
+	return items[m.cursors[c]], true
+}
+
+// setRead marks the selected headline as read or unread
+func (m *model) setRead(item sources.NewsItem, read bool) {
+	key := store.Key(item)
+	m.read[key] = read
+	if m.opts.Store != nil {
+		if err := m.opts.Store.SetRead(context.Background(), item, read); err != nil {
+			m.status = fmt.Sprintf("Okundu bilgisi kaydedilemedi: %v", err)
+		}
+	}
+}
+
+// Update handles key presses, window resizes and fetch results
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+
+	case fetchedMsg:
+		delete(m.loading, msg.category)
+		if _, shown := m.items[msg.category]; msg.err != nil && shown {
+			// Keep showing the news a failed refresh would have replaced
+			m.status = fmt.Sprintf("Yenilenemedi: %v", msg.err)
+			break
+		}
+		if msg.err != nil {
+			m.errs[msg.category] = msg.err
+			break
+		}
+		delete(m.errs, msg.category)
+		m.items[msg.category] = msg.items
+		if m.cursors[msg.category] >= len(msg.items) {
+			m.cursors[msg.category] = max(len(msg.items)-1, 0)
+		}
+		for key, read := range msg.read {
+			m.read[key] = read
+		}
+
+	case tickMsg:
+		return m, tea.Batch(m.fetch(m.current(), true), m.tick())
+
+	case openedMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Tarayıcı açılamadı: %v", msg.err)
+		}
+
+	case tea.KeyMsg:
+		return m, m.handleKey(msg.String())
+	}
+	return m, nil
+}
+
+// handleKey reacts to a key press
+func (m *model) handleKey(key string) tea.Cmd {
+	m.status = ""
+	switch key {
+	case "q", "ctrl+c", "esc":
+		return tea.Quit
+	case "tab":
+		if m.focus == sidebarPane {
+			m.focus = listPane
+		} else {
+			m.focus = sidebarPane
+		}
+	case "left", "h":
+		m.focus = sidebarPane
+	case "right", "l":
+		m.focus = listPane
+	case "up", "k":
+		return m.move(-1)
+	case "down", "j":
+		return m.move(1)
+	case "pgup":
+		return m.move(-m.pageSize())
+	case "pgdown":
+		return m.move(m.pageSize())
+	case "home", "g":
+		return m.move(-len(m.rows) - len(m.items[m.current()]))
+	case "end", "G":
+		return m.move(len(m.rows) + len(m.items[m.current()]))
+	case "r":
+		return m.fetch(m.current(), true)
+	case "enter", "o":
+		if m.focus == sidebarPane && key == "enter" {
+			m.focus = listPane
+			return nil
+		}
+		if item, ok := m.selected(); ok && item.URL != "" {
+			m.setRead(item, true)
+			return openBrowser(item.URL)
+		}
+	case "m":
+		if item, ok := m.selected(); ok {
+			m.setRead(item, !m.read[store.Key(item)])
+		}
+	}
+	return nil
+}
+
+// move moves the cursor of the focused pane by delta lines
+func (m *model) move(delta int) tea.Cmd {
+	if m.focus == listPane {
+		c := m.current()
+		if n := len(m.items[c]); n > 0 {
+			m.cursors[c] = min(max(m.cursors[c]+delta, 0), n-1)
+		}
+		return nil
+	}
+
+	dir := 1
+	if delta < 0 {
+		dir = -1
+	}
+	target := min(max(m.side+delta, 0), len(m.rows)-1)
+	if next := m.nextCategoryRow(target, dir); m.rows[next].index >= 0 {
+		m.side = next
+	} else {
+		m.side = m.nextCategoryRow(target, -dir)
+	}
+
+	// Load categories shown for the first time and retry failed ones
+	c := m.current()
+	if _, ok := m.items[c]; !ok || m.errs[c] != nil {
+		return m.fetch(c, false)
+	}
+	return nil
+}
+
+// pageSize is the number of lines a pane shows
+func (m *model) pageSize() int {
+	return max(m.height-3, 1)
+}
+
+// openBrowser opens url in the default browser of the system
+func openBrowser(url string) tea.Cmd {
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", url)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+		default:
+			cmd = exec.Command("xdg-open", url)
+		}
+		return openedMsg{err: cmd.Start()}
+	}
+}
+
+var (
+	borderStyle   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8"))
+	focusedBorder = borderStyle.BorderForeground(lipgloss.Color("6"))
+	headerStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	cursorStyle   = lipgloss.NewStyle().Reverse(true)
+	selectedStyle = lipgloss.NewStyle().Bold(true)
+	unreadStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+)
+
+// View renders the screen
+func (m *model) View() string {
+	if m.width == 0 {
+		return "Yükleniyor…"
+	}
+
+	height := m.pageSize()
+	sideWidth := min(28, m.width/4)
+	listWidth := (m.width - sideWidth) * 55 / 100
+	previewWidth := m.width - sideWidth - listWidth
+
+	panes := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.pane(sidebarPane, sideWidth, height, m.sidebarLines(sideWidth-2, height)),
+		m.pane(listPane, listWidth, height, m.listLines(listWidth-2, height)),
+		borderStyle.Width(previewWidth-2).Height(height).MaxHeight(height+2).Render(m.preview(previewWidth-2, height)),
+	)
+	return panes + "\n" + m.statusLine()
+}
+
+// pane draws lines inside a bordered box highlighting the focused pane
+func (m *model) pane(p pane, width, height int, lines []string) string {
+	style := borderStyle
+	if m.focus == p {
+		style = focusedBorder
+	}
+	return style.Width(width - 2).Height(height).MaxHeight(height + 2).Render(strings.Join(lines, "\n"))
+}
+
+// sidebarLines renders the visible part of the source and category list
+func (m *model) sidebarLines(width, height int) []string {
+	start, end := window(m.side, len(m.rows), height)
+	var lines []string
+	for i := start; i < end; i++ {
+		r := m.rows[i]
+		switch {
+		case r.index < 0:
+			lines = append(lines, headerStyle.Render(ansi.Truncate(r.label, width, "…")))
+		case i == m.side && m.focus == sidebarPane:
+			lines = append(lines, cursorStyle.Render(pad(ansi.Truncate("  "+r.label, width, "…"), width)))
+		case i == m.side:
+			lines = append(lines, selectedStyle.Render(ansi.Truncate("▸ "+r.label, width, "…")))
+		default:
+			lines = append(lines, ansi.Truncate("  "+r.label, width, "…"))
+		}
+	}
+	return lines
+}
+
+// listLines renders the visible headlines of the selected category
+func (m *model) listLines(width, height int) []string {
+	c := m.current()
+	items := m.items[c]
+	switch {
+	case m.loading[c] && len(items) == 0:
+		return []string{dimStyle.Render("Yükleniyor…")}
+	case m.errs[c] != nil:
+		return []string{unreadStyle.Render(ansi.Truncate("Haberler alınamadı", width, "…")),
+			dimStyle.Width(width).Render(m.errs[c].Error())}
+	case len(items) == 0:
+		return []string{dimStyle.Render("Bu kategoride haber yok.")}
+	}
+
+	cursor := m.cursors[c]
+	start, end := window(cursor, len(items), height)
+	var lines []string
+	for i := start; i < end; i++ {
+		marker := unreadStyle.Render("●") + " "
+		if m.read[store.Key(items[i])] {
+			marker = "  "
+		}
+		title := ansi.Truncate(items[i].Title, width-2, "…")
+		if i == cursor {
+			if m.focus == listPane {
+				title = cursorStyle.Render(pad(title, width-2))
+			} else {
+				title = selectedStyle.Render(title)
+			}
+		}
+		lines = append(lines, marker+title)
+	}
+	return lines
+}
+
+// preview renders the details of the selected headline
+func (m *model) preview(width, height int) string {
+	item, ok := m.selected()
+	if !ok {
+		return ""
+	}
+
+	meta := item.Source + " - " + item.Category
+	if !item.PublishedAt.IsZero() {
+		meta += " · " + item.PublishedAt.Local().Format("02.01.2006 15:04")
+	}
+	if m.opts.Offline && !item.FetchedAt.IsZero() {
+		meta += " · çevrimdışı kopya, " + item.FetchedAt.Local().Format("02.01.2006 15:04")
+	}
+	parts := []string{
+		titleStyle.Width(width).Render(item.Title),
+		dimStyle.Width(width).Render(meta),
+	}
+	if item.Summary != "" {
+		parts = append(parts, "", lipgloss.NewStyle().Width(width).Render(item.Summary))
+	}
+	parts = append(parts, "", dimStyle.Width(width).Render(item.URL))
+
+	lines := strings.Split(strings.Join(parts, "\n"), "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// statusLine renders the key help or the latest status message
+func (m *model) statusLine() string {
+	text := "↑↓ gez · tab bölme değiştir · enter/o tarayıcıda aç · m okundu · r yenile · q çık"
+	if m.status != "" {
+		text = m.status
+	}
+	return dimStyle.Render(ansi.Truncate(text, m.width, "…"))
+}
+
+// window returns the range of n lines to show in height lines so that
+// cursor stays visible
+func window(cursor, n, height int) (start, end int) {
+	if n <= height {
+		return 0, n
+	}
+	start = min(max(cursor-height/2, 0), n-height)
+	return start, start + height
+}
+
+// pad fills s with spaces up to width cells
+func pad(s string, width int) string {
+	if w := ansi.StringWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
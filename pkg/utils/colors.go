@@ -1,7 +1,10 @@
 package utils
 
-// Color constants for terminal output
-const (
+import "os"
+
+// Color codes for terminal output. They are variables so that they can be
+// blanked out with DisableColors when the output is not a terminal.
+var (
 	Reset  = "\033[0m"
 	Red    = "\033[31m"
 	Green  = "\033[32m"
@@ -12,5 +15,19 @@ const (
 	Gray   = "\033[37m"
 )
 
+// DisableColors turns all color codes into empty strings
+func DisableColors() {
+	Reset, Red, Green, Yellow, Blue, Purple, Cyan, Gray = "", "", "", "", "", "", "", ""
+}
+
+// IsTerminal reports whether f is connected to a terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 // Version of the application
-const Version = "2.0.0" 
\ No newline at end of file
+const Version = "2.0.0"